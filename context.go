@@ -0,0 +1,114 @@
+package xmapper
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ValidatorContextFunc defines the type for validator functions that receive the context of the mapping call,
+// e.g. to look up a value in a database while honoring cancellation and deadlines.
+type ValidatorContextFunc func(ctx context.Context, value interface{}, arg string) error
+
+// TransformerContextFunc defines the type for transformer functions that receive the context of the mapping call.
+// Like a ParamTransformerFunc it receives the argument of the tag and can fail, e.g. when a lookup times out.
+type TransformerContextFunc func(ctx context.Context, value interface{}, arg string) (interface{}, error)
+
+// validateFunc is the form every registered validator is stored in.
+type validateFunc func(ctx context.Context, value interface{}, arg string, parent Parent) error
+
+// transformFunc is the form every registered transformer is stored in.
+type transformFunc func(ctx context.Context, value interface{}, arg string) (interface{}, error)
+
+// RegisterValidatorContext adds a context-aware validator function to the registry of the default Mapper.
+func RegisterValidatorContext(name string, f ValidatorContextFunc) {
+	defaultMapper.RegisterValidatorContext(name, f)
+}
+
+// RegisterTransformerContext adds a context-aware transformer function to the registry of the default Mapper.
+func RegisterTransformerContext(name string, f TransformerContextFunc) {
+	defaultMapper.RegisterTransformerContext(name, f)
+}
+
+// MapStructsContext is like MapStructs but passes ctx to validators, transformers and ValidateContext hooks.
+func MapStructsContext(ctx context.Context, src, dest interface{}) error {
+	return defaultMapper.MapStructsContext(ctx, src, dest)
+}
+
+// ValidateStructContext is like ValidateStruct but passes ctx to validators, transformers and ValidateContext hooks.
+func ValidateStructContext(ctx context.Context, s interface{}) error {
+	return defaultMapper.ValidateStructContext(ctx, s)
+}
+
+// MapJsonStructContext is like MapJsonStruct but passes ctx to validators, transformers and ValidateContext hooks.
+func MapJsonStructContext(ctx context.Context, jsonStr string, target interface{}) error {
+	return defaultMapper.MapJsonStructContext(ctx, jsonStr, target)
+}
+
+// RegisterValidatorContext adds a context-aware validator function to the registry of m.
+func (m *Mapper) RegisterValidatorContext(name string, f ValidatorContextFunc) {
+	m.registerValidator(name, registeredValidator{fn: func(ctx context.Context, value interface{}, arg string, _ Parent) error {
+		return f(ctx, value, arg)
+	}})
+}
+
+// RegisterTransformerContext adds a context-aware transformer function to the registry of m.
+func (m *Mapper) RegisterTransformerContext(name string, f TransformerContextFunc) {
+	m.registerTransformer(name, registeredTransformer{fn: transformFunc(f)})
+}
+
+// MapStructsContext validates, transforms and maps data from source struct to destination struct.
+// The mapping stops with the error of ctx as soon as ctx is done, it is checked before every field and slice element.
+func (m *Mapper) MapStructsContext(ctx context.Context, src, dest interface{}) error {
+	srcValue := reflect.ValueOf(src)
+	destValue := reflect.ValueOf(dest)
+	if !isValidStructPointer(srcValue) || !isValidStructPointer(destValue) {
+		return errors.New("both source and destination must be pointer to a struct")
+	}
+
+	state := m.newState(ctx)
+	if err := mapStructsRecursive(state, "", srcValue, destValue); err != nil {
+		return err
+	}
+	return state.result()
+}
+
+// ValidateStructContext validates the struct fields against defined validators.
+// The validation stops with the error of ctx as soon as ctx is done.
+func (m *Mapper) ValidateStructContext(ctx context.Context, s interface{}) error {
+	val := reflect.ValueOf(s)
+	if !isValidStructPointer(val) {
+		return fmt.Errorf("input must be a pointer to a struct")
+	}
+
+	state := m.newState(ctx)
+	if err := validateStructRecursive(state, "", val); err != nil {
+		return err
+	}
+	return state.result()
+}
+
+// MapJsonStructContext decodes a JSON string into the provided struct pointer and applies any necessary validations and transformations.
+// The validation stops with the error of ctx as soon as ctx is done.
+func (m *Mapper) MapJsonStructContext(ctx context.Context, jsonStr string, target interface{}) error {
+	if reflect.ValueOf(target).Kind() != reflect.Ptr {
+		return fmt.Errorf("target must be a pointer to a struct")
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	err := json.Unmarshal([]byte(jsonStr), target)
+	if err != nil {
+		return err
+	}
+
+	return m.MapStructsContext(ctx, target, target)
+}
+
+// newState creates the state of a top-level call that runs with the given context.
+func (m *Mapper) newState(ctx context.Context) *mappingState {
+	return &mappingState{mapper: m, ctx: ctx}
+}
@@ -0,0 +1,278 @@
+package xmapper
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// FloatConversion decides how floats with a fractional part are mapped to integer fields.
+type FloatConversion int
+
+const (
+	// FloatReject fails the mapping when a float with a fractional part is mapped to an integer field. This is the default.
+	FloatReject FloatConversion = iota
+	// FloatTruncate drops the fractional part, e.g. 2.7 becomes 2 and -2.7 becomes -2.
+	FloatTruncate
+	// FloatRound rounds to the nearest integer, halves away from zero, e.g. 2.5 becomes 3.
+	FloatRound
+)
+
+// WithFloatConversion sets how floats with a fractional part are mapped to integer fields.
+func WithFloatConversion(conversion FloatConversion) Option {
+	return func(m *Mapper) {
+		m.floatConversion = conversion
+	}
+}
+
+// converterKey identifies a converter by its exact source and destination types.
+type converterKey struct {
+	src, dest reflect.Type
+}
+
+// convertFunc is a registered converter, it takes a value of the source type and returns a value of the destination type.
+type convertFunc func(value reflect.Value) (reflect.Value, error)
+
+// RegisterConverterOn adds a converter from S to D to the registry of m, replacing any converter for the same types.
+// Whenever a value of exactly type S is mapped to a field of exactly type D, including struct fields, slice elements
+// and whole slices, the converter is used instead of the built-in rules. Its errors are returned as a ConversionError.
+func RegisterConverterOn[S, D any](m *Mapper, f func(S) (D, error)) {
+	key := converterKey{src: reflect.TypeOf((*S)(nil)).Elem(), dest: reflect.TypeOf((*D)(nil)).Elem()}
+	convert := func(value reflect.Value) (reflect.Value, error) {
+		result, err := f(value.Interface().(S))
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(&result).Elem(), nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.converters[key] = convert
+}
+
+// WithConverter registers a converter from S to D on the new Mapper, see RegisterConverterOn.
+func WithConverter[S, D any](f func(S) (D, error)) Option {
+	return func(m *Mapper) {
+		RegisterConverterOn(m, f)
+	}
+}
+
+// lookupConverter returns the converter registered for the exact source and destination types.
+func (m *Mapper) lookupConverter(src, dest reflect.Type) (convertFunc, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	f, exists := m.converters[converterKey{src: src, dest: dest}]
+	return f, exists
+}
+
+// convertValue converts the value to the given type. A registered converter for the exact types comes first.
+// Besides assignable values it converts between every integer and float type, failing on overflow, between strings
+// and numbers or booleans, and between named types with the same underlying kind, e.g. "type Status string" and string.
+func (m *Mapper) convertValue(value reflect.Value, to reflect.Type) (reflect.Value, error) {
+	if convert, ok := m.lookupConverter(value.Type(), to); ok {
+		return convert(value)
+	}
+	if value.Type().AssignableTo(to) {
+		return value, nil
+	}
+
+	result := reflect.New(to).Elem()
+	switch {
+	case isIntKind(to.Kind()):
+		n, err := m.toInt64(value, to)
+		if err != nil {
+			return result, err
+		}
+		if result.OverflowInt(n) {
+			return result, fmt.Errorf("%v overflows %s", value, to)
+		}
+		result.SetInt(n)
+		return result, nil
+	case isUintKind(to.Kind()):
+		n, err := m.toUint64(value, to)
+		if err != nil {
+			return result, err
+		}
+		if result.OverflowUint(n) {
+			return result, fmt.Errorf("%v overflows %s", value, to)
+		}
+		result.SetUint(n)
+		return result, nil
+	case to.Kind() == reflect.Float32 || to.Kind() == reflect.Float64:
+		f, err := toFloat64(value, to)
+		if err != nil {
+			return result, err
+		}
+		if result.OverflowFloat(f) {
+			return result, fmt.Errorf("%v overflows %s", value, to)
+		}
+		result.SetFloat(f)
+		return result, nil
+	case to.Kind() == reflect.String:
+		str, ok := formatValue(value)
+		if !ok {
+			break
+		}
+		result.SetString(str)
+		return result, nil
+	case to.Kind() == reflect.Bool && value.Kind() == reflect.String:
+		b, err := strconv.ParseBool(strings.TrimSpace(value.String()))
+		if err != nil {
+			return result, fmt.Errorf("%q is not a valid %s", value.String(), to)
+		}
+		result.SetBool(b)
+		return result, nil
+	}
+
+	// Named types such as "type Status string" convert to and from their underlying type
+	if value.Kind() == to.Kind() && value.Type().ConvertibleTo(to) {
+		return value.Convert(to), nil
+	}
+	return result, fmt.Errorf("%s is not assignable to %s", value.Type(), to)
+}
+
+// toInt64 converts an integer, a float or a string holding an integer to an int64.
+func (m *Mapper) toInt64(value reflect.Value, to reflect.Type) (int64, error) {
+	switch {
+	case isIntKind(value.Kind()):
+		return value.Int(), nil
+	case isUintKind(value.Kind()):
+		if value.Uint() > math.MaxInt64 {
+			return 0, fmt.Errorf("%v overflows %s", value, to)
+		}
+		return int64(value.Uint()), nil
+	case value.Kind() == reflect.Float32 || value.Kind() == reflect.Float64:
+		f, err := m.integral(value.Float(), to)
+		if err != nil {
+			return 0, err
+		}
+		// float64(math.MaxInt64) rounds up to 2^63, which does not fit
+		if f < math.MinInt64 || f >= math.MaxInt64 {
+			return 0, fmt.Errorf("%v overflows %s", value, to)
+		}
+		return int64(f), nil
+	case value.Kind() == reflect.String:
+		n, err := strconv.ParseInt(strings.TrimSpace(value.String()), 10, 64)
+		if err != nil {
+			return 0, parseError(value.String(), to, err)
+		}
+		return n, nil
+	}
+	return 0, fmt.Errorf("%s is not assignable to %s", value.Type(), to)
+}
+
+// toUint64 converts a non-negative integer, a float or a string holding an unsigned integer to a uint64.
+func (m *Mapper) toUint64(value reflect.Value, to reflect.Type) (uint64, error) {
+	switch {
+	case isIntKind(value.Kind()):
+		if value.Int() < 0 {
+			return 0, fmt.Errorf("%v overflows %s", value, to)
+		}
+		return uint64(value.Int()), nil
+	case isUintKind(value.Kind()):
+		return value.Uint(), nil
+	case value.Kind() == reflect.Float32 || value.Kind() == reflect.Float64:
+		f, err := m.integral(value.Float(), to)
+		if err != nil {
+			return 0, err
+		}
+		if f < 0 || f >= math.MaxUint64 {
+			return 0, fmt.Errorf("%v overflows %s", value, to)
+		}
+		return uint64(f), nil
+	case value.Kind() == reflect.String:
+		n, err := strconv.ParseUint(strings.TrimSpace(value.String()), 10, 64)
+		if err != nil {
+			return 0, parseError(value.String(), to, err)
+		}
+		return n, nil
+	}
+	return 0, fmt.Errorf("%s is not assignable to %s", value.Type(), to)
+}
+
+// integral applies the float conversion of m to a float that is mapped to an integer field.
+func (m *Mapper) integral(f float64, to reflect.Type) (float64, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, fmt.Errorf("%v cannot be mapped to %s", f, to)
+	}
+	switch m.floatConversion {
+	case FloatTruncate:
+		return math.Trunc(f), nil
+	case FloatRound:
+		return math.Round(f), nil
+	}
+	if f != math.Trunc(f) {
+		return 0, fmt.Errorf("%v has a fractional part and cannot be mapped to %s", f, to)
+	}
+	return f, nil
+}
+
+// toFloat64 converts a number or a string holding a number to a float64.
+func toFloat64(value reflect.Value, to reflect.Type) (float64, error) {
+	switch {
+	case isIntKind(value.Kind()):
+		return float64(value.Int()), nil
+	case isUintKind(value.Kind()):
+		return float64(value.Uint()), nil
+	case value.Kind() == reflect.Float32 || value.Kind() == reflect.Float64:
+		return value.Float(), nil
+	case value.Kind() == reflect.String:
+		f, err := strconv.ParseFloat(strings.TrimSpace(value.String()), to.Bits())
+		if err != nil {
+			return 0, parseError(value.String(), to, err)
+		}
+		return f, nil
+	}
+	return 0, fmt.Errorf("%s is not assignable to %s", value.Type(), to)
+}
+
+// formatValue formats a string, number or boolean as a string.
+// Integers are formatted as numbers, unlike reflect.Value.Convert which turns them into runes.
+func formatValue(value reflect.Value) (string, bool) {
+	switch {
+	case value.Kind() == reflect.String:
+		return value.String(), true
+	case isIntKind(value.Kind()):
+		return strconv.FormatInt(value.Int(), 10), true
+	case isUintKind(value.Kind()):
+		return strconv.FormatUint(value.Uint(), 10), true
+	case value.Kind() == reflect.Float32:
+		return strconv.FormatFloat(value.Float(), 'f', -1, 32), true
+	case value.Kind() == reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'f', -1, 64), true
+	case value.Kind() == reflect.Bool:
+		return strconv.FormatBool(value.Bool()), true
+	}
+	return "", false
+}
+
+// parseError describes a string that could not be parsed as a number of the given type.
+func parseError(str string, to reflect.Type, err error) error {
+	if numErr, ok := err.(*strconv.NumError); ok && numErr.Err == strconv.ErrRange {
+		return fmt.Errorf("%q overflows %s", str, to)
+	}
+	return fmt.Errorf("%q is not a valid %s", str, to)
+}
+
+// isIntKind reports whether the kind is a signed integer kind.
+func isIntKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	default:
+		return false
+	}
+}
+
+// isUintKind reports whether the kind is an unsigned integer kind.
+func isUintKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	default:
+		return false
+	}
+}
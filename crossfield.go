@@ -0,0 +1,278 @@
+package xmapper
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/dev3mike/go-xmapper/validators"
+)
+
+// Parent gives cross-field validators access to the struct, or map, enclosing the validated field.
+type Parent struct {
+	value  reflect.Value
+	mapper *Mapper
+}
+
+// parent wraps the value enclosing the fields that are currently validated.
+func (s *mappingState) parent(value reflect.Value) Parent {
+	return Parent{value: value, mapper: s.mapper}
+}
+
+// Interface returns the enclosing struct or map, or nil when there is none, e.g. in ValidateSingleField.
+func (p Parent) Interface() interface{} {
+	if !p.value.IsValid() || !p.value.CanInterface() {
+		return nil
+	}
+	return p.value.Interface()
+}
+
+// Field returns the value of a sibling field by its name, using the same names and matching strategy as mapping.
+// Nested fields are separated by dots, e.g. "address.country". It reports false when the field does not exist.
+func (p Parent) Field(path string) (interface{}, bool) {
+	if !p.value.IsValid() || path == "" {
+		return nil, false
+	}
+
+	current := p.value
+	for _, name := range strings.Split(path, ".") {
+		for current.Kind() == reflect.Ptr || current.Kind() == reflect.Interface {
+			if current.IsNil() {
+				return nil, false
+			}
+			current = current.Elem()
+		}
+
+		switch current.Kind() {
+		case reflect.Struct:
+			next, ok := p.structField(current, name)
+			if !ok {
+				return nil, false
+			}
+			current = next
+		case reflect.Map:
+			if current.Type().Key().Kind() != reflect.String {
+				return nil, false
+			}
+			current = p.mapper.lookupMapKey(current, name)
+			if !current.IsValid() {
+				return nil, false
+			}
+		default:
+			return nil, false
+		}
+	}
+
+	if !current.CanInterface() {
+		return nil, false
+	}
+	return current.Interface(), true
+}
+
+// structField returns the field of the struct with the given name.
+func (p Parent) structField(structValue reflect.Value, name string) (reflect.Value, bool) {
+	key := p.mapper.matchKey(name)
+	for i := 0; i < structValue.NumField(); i++ {
+		fieldName := p.mapper.fieldName(structValue.Type().Field(i))
+		if fieldName != "" && p.mapper.matchKey(fieldName) == key {
+			return structValue.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// otherField returns the value of the referenced sibling field or an error if it does not exist.
+func (p Parent) otherField(path string) (interface{}, error) {
+	other, ok := p.Field(path)
+	if !ok {
+		return nil, fmt.Errorf("field '%s' not found", path)
+	}
+	return other, nil
+}
+
+// eqFieldValidator checks if the input is equal to the referenced field
+func eqFieldValidator(input interface{}, field string, parent Parent) error {
+	other, err := parent.otherField(field)
+	if err != nil {
+		return err
+	}
+	if !equalValues(input, other) {
+		return fmt.Errorf("input must be equal to field '%s'", field)
+	}
+	return nil
+}
+
+// neFieldValidator checks if the input is not equal to the referenced field
+func neFieldValidator(input interface{}, field string, parent Parent) error {
+	other, err := parent.otherField(field)
+	if err != nil {
+		return err
+	}
+	if equalValues(input, other) {
+		return fmt.Errorf("input must not be equal to field '%s'", field)
+	}
+	return nil
+}
+
+// gtFieldValidator checks if the input is greater than the referenced field, for numbers, strings and times
+func gtFieldValidator(input interface{}, field string, parent Parent) error {
+	if isBlank(input) {
+		return nil
+	}
+
+	other, err := parent.otherField(field)
+	if err != nil {
+		return err
+	}
+	result, err := compareValues(input, other)
+	if err != nil {
+		return err
+	}
+	if result <= 0 {
+		return fmt.Errorf("input must be greater than field '%s'", field)
+	}
+	return nil
+}
+
+// ltFieldValidator checks if the input is less than the referenced field, for numbers, strings and times
+func ltFieldValidator(input interface{}, field string, parent Parent) error {
+	if isBlank(input) {
+		return nil
+	}
+
+	other, err := parent.otherField(field)
+	if err != nil {
+		return err
+	}
+	result, err := compareValues(input, other)
+	if err != nil {
+		return err
+	}
+	if result >= 0 {
+		return fmt.Errorf("input must be less than field '%s'", field)
+	}
+	return nil
+}
+
+// requiredWithValidator checks if the input is not empty when the referenced field is not empty
+func requiredWithValidator(input interface{}, field string, parent Parent) error {
+	other, err := parent.otherField(field)
+	if err != nil {
+		return err
+	}
+	if !isBlank(other) && isBlank(input) {
+		return fmt.Errorf("input is required when field '%s' is set", field)
+	}
+	return nil
+}
+
+// requiredIfValidator checks if the input is not empty when the referenced field has a given value,
+// the argument is the field name and the value separated by a space, e.g. "country DE"
+func requiredIfValidator(input interface{}, arg string, parent Parent) error {
+	field, expected, found := strings.Cut(strings.TrimSpace(arg), " ")
+	if !found {
+		return fmt.Errorf("requiredIf format is incorrect, must be 'field value'")
+	}
+	expected = strings.TrimSpace(expected)
+
+	other, err := parent.otherField(field)
+	if err != nil {
+		return err
+	}
+	if fmt.Sprint(indirect(other)) == expected && isBlank(input) {
+		return fmt.Errorf("input is required when field '%s' is '%s'", field, expected)
+	}
+	return nil
+}
+
+// requiredWithoutValidator checks if the input is not empty when the referenced field is empty
+func requiredWithoutValidator(input interface{}, field string, parent Parent) error {
+	other, err := parent.otherField(field)
+	if err != nil {
+		return err
+	}
+	if isBlank(other) && isBlank(input) {
+		return fmt.Errorf("input is required when field '%s' is not set", field)
+	}
+	return nil
+}
+
+// requiredUnlessValidator checks if the input is not empty unless the referenced field has a given value,
+// the argument is the field name and the value separated by a space, e.g. "country DE"
+func requiredUnlessValidator(input interface{}, arg string, parent Parent) error {
+	field, expected, found := strings.Cut(strings.TrimSpace(arg), " ")
+	if !found {
+		return fmt.Errorf("requiredUnless format is incorrect, must be 'field value'")
+	}
+	expected = strings.TrimSpace(expected)
+
+	other, err := parent.otherField(field)
+	if err != nil {
+		return err
+	}
+	if fmt.Sprint(indirect(other)) != expected && isBlank(input) {
+		return fmt.Errorf("input is required unless field '%s' is '%s'", field, expected)
+	}
+	return nil
+}
+
+// indirect dereferences pointers and returns the underlying value, or nil for nil pointers.
+func indirect(input interface{}) interface{} {
+	value := reflect.ValueOf(input)
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	if !value.IsValid() {
+		return nil
+	}
+	return value.Interface()
+}
+
+// isBlank reports whether the input is nil, a nil pointer, a blank string, an empty collection or a zero value.
+func isBlank(input interface{}) bool {
+	value := reflect.ValueOf(indirect(input))
+	switch value.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.String:
+		return strings.TrimSpace(value.String()) == ""
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return value.Len() == 0
+	default:
+		return value.IsZero()
+	}
+}
+
+// compareValues compares two numbers, strings or times and returns -1, 0 or 1.
+// Numbers are compared exactly, so large integers do not lose precision as they would in float64.
+func compareValues(a, b interface{}) (int, error) {
+	a, b = indirect(a), indirect(b)
+
+	if timeA, ok := a.(time.Time); ok {
+		if timeB, ok := b.(time.Time); ok {
+			return timeA.Compare(timeB), nil
+		}
+	}
+	if result, ok := validators.CompareNumbers(a, b); ok {
+		return result, nil
+	}
+
+	valueA, valueB := reflect.ValueOf(a), reflect.ValueOf(b)
+	if valueA.Kind() == reflect.String && valueB.Kind() == reflect.String {
+		return strings.Compare(valueA.String(), valueB.String()), nil
+	}
+	return 0, fmt.Errorf("cannot compare %T with %T", a, b)
+}
+
+// equalValues reports whether two values are equal, numbers of different types are equal when their values are.
+func equalValues(a, b interface{}) bool {
+	a, b = indirect(a), indirect(b)
+	if result, ok := validators.CompareNumbers(a, b); ok {
+		return result == 0
+	}
+	return reflect.DeepEqual(a, b)
+}
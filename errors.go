@@ -0,0 +1,211 @@
+package xmapper
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// FieldError describes a single validator that failed for a single field.
+type FieldError struct {
+	// Path is the full path of the field, e.g. "address.lines[2]". It is empty for ValidateSingleField.
+	Path string
+	// Validator is the name of the failing validator as written in the tag.
+	Validator string
+	// Arg is the argument as written in the tag, e.g. "5" for "minLength:5" or "'a'|'b'" for "enum:'a'|'b'".
+	Arg string
+	// Value is the value that failed validation.
+	Value interface{}
+	// Message is the message reported by the validator.
+	Message string
+	// Err is the error returned by the validator.
+	Err error
+	// Key is true when the validator failed for a map key rather than its value, Path then names the entry of the key.
+	Key bool
+}
+
+// Error implements the error interface.
+func (e *FieldError) Error() string {
+	if e.Key {
+		return fmt.Sprintf("validation failed for the key of '%s' (%s): %s", e.Path, e.Validator, e.Message)
+	}
+	if e.Path == "" {
+		return fmt.Sprintf("validation failed for validator '%s': %s", e.Validator, e.Message)
+	}
+	return fmt.Sprintf("validation failed for field '%s' (%s): %s", e.Path, e.Validator, e.Message)
+}
+
+// Unwrap returns the error reported by the validator.
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the target is ErrValidation, so errors.Is(err, ErrValidation) keeps working.
+func (e *FieldError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// TransformationError describes a transformer that failed for a single field.
+type TransformationError struct {
+	// Path is the full path of the field, e.g. "address.lines[2]". It is empty for ValidateSingleField.
+	Path string
+	// Transformer is the name of the failing transformer as written in the tag.
+	Transformer string
+	// Value is the value passed to the transformer.
+	Value interface{}
+	// Err is the error returned by the transformer.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *TransformationError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("transformation failed for transformer '%s': %s", e.Transformer, e.Err)
+	}
+	return fmt.Sprintf("transformation failed for field '%s' (%s): %s", e.Path, e.Transformer, e.Err)
+}
+
+// Unwrap returns the error reported by the transformer.
+func (e *TransformationError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the target is ErrTransformation, so errors.Is(err, ErrTransformation) works.
+func (e *TransformationError) Is(target error) bool {
+	return target == ErrTransformation
+}
+
+// ConversionError describes a value that cannot be converted to the type of its destination field.
+type ConversionError struct {
+	// Path is the full path of the field, e.g. "address.lines[2]".
+	Path string
+	// Value is the value that could not be converted.
+	Value interface{}
+	// Type is the type of the destination field.
+	Type reflect.Type
+	// Err describes why the conversion failed, e.g. an overflow.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *ConversionError) Error() string {
+	return fmt.Sprintf("cannot map field '%s': %s", e.Path, e.Err)
+}
+
+// Unwrap returns the error that describes the failed conversion.
+func (e *ConversionError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the target is ErrConversion, so errors.Is(err, ErrConversion) works.
+func (e *ConversionError) Is(target error) bool {
+	return target == ErrConversion
+}
+
+// BranchError is the failure of one alternative of an expression like "email|phone".
+type BranchError struct {
+	// Validator is the alternative as written in the tag, e.g. "email" or "(minLength:3,alpha)".
+	Validator string
+	// Err is the error reported by the alternative.
+	Err error
+}
+
+// AlternativesError is reported by an expression like "email|phone" when every alternative failed.
+type AlternativesError struct {
+	// Branches holds the failure of each alternative in the order they were written.
+	Branches []BranchError
+}
+
+// Error implements the error interface.
+func (e *AlternativesError) Error() string {
+	messages := make([]string, len(e.Branches))
+	for i, branch := range e.Branches {
+		messages[i] = fmt.Sprintf("%s: %s", branch.Validator, branch.Err)
+	}
+	return "none of the alternatives passed: " + strings.Join(messages, "; ")
+}
+
+// Unwrap returns the errors of the alternatives so errors.As can reach them.
+func (e *AlternativesError) Unwrap() []error {
+	errs := make([]error, len(e.Branches))
+	for i, branch := range e.Branches {
+		errs[i] = branch.Err
+	}
+	return errs
+}
+
+// ValidationErrors collects every validation failure found during a single mapping or validation call.
+type ValidationErrors []*FieldError
+
+// Error implements the error interface.
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, fieldErr := range e {
+		messages[i] = fieldErr.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Is reports whether the target is ErrValidation.
+func (e ValidationErrors) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// Unwrap returns the individual field errors so errors.As can reach them.
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, fieldErr := range e {
+		errs[i] = fieldErr
+	}
+	return errs
+}
+
+// mappingState carries the state shared by a single top-level mapping or validation call.
+type mappingState struct {
+	mapper *Mapper
+	ctx    context.Context
+	errs   ValidationErrors
+}
+
+// addValidationError records a failed validator for the field at the given path.
+// Combined validators such as "email|phone" are reported as written, without an argument.
+func (s *mappingState) addValidationError(path string, validator validatorNode, value interface{}, err error) {
+	name, arg := validator.String(), ""
+	if v, ok := validator.(fieldValidator); ok {
+		name, arg = v.name, v.arg
+	}
+	s.errs = append(s.errs, &FieldError{
+		Path:      path,
+		Validator: name,
+		Arg:       arg,
+		Value:     value,
+		Message:   err.Error(),
+		Err:       err,
+	})
+}
+
+// result returns the collected validation errors, or nil if every validator passed.
+// The error of the context takes precedence, as the errors may be incomplete when it was cancelled.
+func (s *mappingState) result() error {
+	if err := s.ctx.Err(); err != nil {
+		return err
+	}
+	if len(s.errs) == 0 {
+		return nil
+	}
+	return s.errs
+}
+
+// joinPath appends a field name to a parent path.
+func joinPath(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
+
+// indexPath appends a slice index to a parent path.
+func indexPath(parent string, index int) string {
+	return fmt.Sprintf("%s[%d]", parent, index)
+}
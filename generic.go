@@ -0,0 +1,119 @@
+package xmapper
+
+import (
+	"context"
+	"errors"
+	"reflect"
+)
+
+// Map validates, transforms and maps src into a new value of type D using the default Mapper.
+// src may be a struct or a pointer to a struct, D may be a struct type or a pointer to a struct type.
+func Map[D any](src any) (D, error) {
+	return MapWith[D](defaultMapper, src)
+}
+
+// MapWith is like Map but uses the given Mapper.
+func MapWith[D any](m *Mapper, src any) (D, error) {
+	var dest D
+	state := m.newState(context.Background())
+	if err := mapStructValue(state, "", reflect.ValueOf(src), reflect.ValueOf(&dest).Elem()); err != nil {
+		var zero D
+		return zero, err
+	}
+	if err := state.result(); err != nil {
+		var zero D
+		return zero, err
+	}
+	return dest, nil
+}
+
+// MapInto validates, transforms and maps src into dest using the default Mapper.
+// It is the type-checked equivalent of MapStructs.
+func MapInto[S, D any](src *S, dest *D) error {
+	return MapIntoWith(defaultMapper, src, dest)
+}
+
+// MapIntoWith is like MapInto but uses the given Mapper.
+func MapIntoWith[S, D any](m *Mapper, src *S, dest *D) error {
+	if src == nil || dest == nil {
+		return errors.New("both source and destination must not be nil")
+	}
+	state := m.newState(context.Background())
+	if err := mapStructValue(state, "", reflect.ValueOf(src), reflect.ValueOf(dest).Elem()); err != nil {
+		return err
+	}
+	return state.result()
+}
+
+// MapSlice maps every element of src into a new slice of D using the default Mapper.
+// Elements may be structs or pointers to structs on either side, nil source elements become zero values.
+// Validation errors of all elements are collected, each path starts with the element index, e.g. "[2].email".
+func MapSlice[S, D any](src []S) ([]D, error) {
+	return MapSliceWith[S, D](defaultMapper, src)
+}
+
+// MapSliceWith is like MapSlice but uses the given Mapper.
+func MapSliceWith[S, D any](m *Mapper, src []S) ([]D, error) {
+	dest := make([]D, len(src))
+	state := m.newState(context.Background())
+	if err := mapSliceValue(state, reflect.ValueOf(src), reflect.ValueOf(dest)); err != nil {
+		return nil, err
+	}
+	if err := state.result(); err != nil {
+		return nil, err
+	}
+	return dest, nil
+}
+
+// mapSliceValue maps each element of the source slice into the element with the same index of the destination slice.
+func mapSliceValue(state *mappingState, srcSlice, destSlice reflect.Value) error {
+	for i := 0; i < srcSlice.Len(); i++ {
+		if err := state.ctx.Err(); err != nil {
+			return err
+		}
+		srcElem := srcSlice.Index(i)
+		if isNilPointer(srcElem) {
+			continue
+		}
+		if err := mapStructValue(state, indexPath("", i), srcElem, destSlice.Index(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mapStructValue maps a struct or a pointer to a struct into dest, which must be a settable struct or pointer to a struct.
+// Unaddressable source structs are copied so they can be mapped like any other struct.
+func mapStructValue(state *mappingState, path string, src, dest reflect.Value) error {
+	for src.Kind() == reflect.Ptr || src.Kind() == reflect.Interface {
+		if src.IsNil() {
+			return errors.New("source must not be nil")
+		}
+		src = src.Elem()
+	}
+	if src.Kind() != reflect.Struct {
+		return errors.New("source must be a struct or a pointer to a struct")
+	}
+	if !src.CanAddr() {
+		srcCopy := reflect.New(src.Type()).Elem()
+		srcCopy.Set(src)
+		src = srcCopy
+	}
+
+	if dest.Kind() == reflect.Ptr {
+		if dest.IsNil() {
+			dest.Set(reflect.New(dest.Type().Elem()))
+		}
+		dest = dest.Elem()
+	}
+	if dest.Kind() != reflect.Struct {
+		return errors.New("destination must be a struct or a pointer to a struct")
+	}
+
+	return mapStructsRecursive(state, path, src.Addr(), dest.Addr())
+}
+
+// isNilPointer reports whether the value is a nil pointer or a nil interface.
+func isNilPointer(value reflect.Value) bool {
+	return (value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface) && value.IsNil()
+}
@@ -0,0 +1,78 @@
+package xmapper
+
+import (
+	"context"
+	"errors"
+	"reflect"
+)
+
+// Validatable is implemented by structs that check invariants spanning several fields.
+// Validate is called after the field-level validators of the struct have run.
+type Validatable interface {
+	Validate() error
+}
+
+// ContextValidatable is the context-aware variant of Validatable.
+type ContextValidatable interface {
+	ValidateContext(ctx context.Context) error
+}
+
+// runStructHooks calls Validate and ValidateContext on the struct if it implements them,
+// and records the returned errors as validation errors of the struct's path.
+func (s *mappingState) runStructHooks(path string, structValue reflect.Value) {
+	if structValue.CanAddr() {
+		structValue = structValue.Addr()
+	}
+	if !structValue.CanInterface() {
+		return
+	}
+
+	target := structValue.Interface()
+	if validatable, ok := target.(Validatable); ok {
+		s.addHookError(path, "Validate", target, validatable.Validate())
+	}
+	if validatable, ok := target.(ContextValidatable); ok {
+		s.addHookError(path, "ValidateContext", target, validatable.ValidateContext(s.ctx))
+	}
+}
+
+// addHookError folds the error returned by a struct hook into the validation errors.
+// Field errors returned by the hook keep their own path, prefixed with the path of the struct.
+func (s *mappingState) addHookError(path, hook string, value interface{}, err error) {
+	if err == nil {
+		return
+	}
+
+	var validationErrs ValidationErrors
+	var fieldErr *FieldError
+	switch {
+	case errors.As(err, &validationErrs):
+		for _, fieldErr := range validationErrs {
+			s.addFieldError(path, fieldErr)
+		}
+	case errors.As(err, &fieldErr):
+		s.addFieldError(path, fieldErr)
+	default:
+		s.errs = append(s.errs, &FieldError{
+			Path:      path,
+			Validator: hook,
+			Value:     value,
+			Message:   err.Error(),
+			Err:       err,
+		})
+	}
+}
+
+// addFieldError records a copy of the field error with its path prefixed by the given path.
+func (s *mappingState) addFieldError(path string, fieldErr *FieldError) {
+	prefixed := *fieldErr
+	switch {
+	case fieldErr.Path == "":
+		prefixed.Path = path
+	case fieldErr.Path[0] == '[':
+		prefixed.Path = path + fieldErr.Path
+	default:
+		prefixed.Path = joinPath(path, fieldErr.Path)
+	}
+	s.errs = append(s.errs, &prefixed)
+}
@@ -1,15 +1,13 @@
 package xmapper
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"reflect"
 	"strings"
 	"time"
-
-	"github.com/dev3mike/go-xmapper/transformers"
-	"github.com/dev3mike/go-xmapper/validators"
 )
 
 // TransformerFunc defines the type for functions that transform data from one form to another.
@@ -18,76 +16,86 @@ type TransformerFunc func(interface{}) interface{}
 // ValidatorFunc defines the type for functions that validate data.
 type ValidatorFunc func(interface{}, string) error
 
+// CrossFieldValidatorFunc defines the type for functions that validate data against other fields of the enclosing struct.
+type CrossFieldValidatorFunc func(value interface{}, arg string, parent Parent) error
+
+// FallibleTransformerFunc defines the type for transformer functions that can fail, e.g. because the input cannot be decoded.
+type FallibleTransformerFunc func(interface{}) (interface{}, error)
+
+// ParamTransformerFunc defines the type for transformer functions that take the argument written after the transformer name, e.g. "50" for "truncate:50".
+type ParamTransformerFunc func(value interface{}, arg string) (interface{}, error)
+
 // ErrValidation: Validation methods return this error in case of an error, so you can use it to catch validation errors
 var ErrValidation = errors.New("ValidationError")
 
-// transformerRegistry is a map that holds registered transformer functions keyed by their name.
-var transformerRegistry = map[string]TransformerFunc{}
-
-// validatorRegistry holds registered validator functions keyed by their name.
-var validatorRegistry = map[string]ValidatorFunc{}
-
-func init() {
-	// Default validators
-	RegisterValidator("required", validators.RequiredValidator) // Should not be empty
-	RegisterValidator("email", validators.EmailValidator)
-	RegisterValidator("phone", validators.PhoneValidator)                   // International phone number format
-	RegisterValidator("strongPassword", validators.StrongPasswordValidator) // Minimum 8 characters, at least one uppercase, one lowercase, one number, and one special character
-	RegisterValidator("date", validators.DateValidator)                     // Date in YYYY-MM-DD format
-	RegisterValidator("time", validators.TimeValidator)                     // Time in HH:MM:SS format
-	RegisterValidator("datetime", validators.DatetimeValidator)             // Date and time in YYYY-MM-DD HH:MM:SS format with timezone
-	RegisterValidator("url", validators.UrlValidator)
-	RegisterValidator("ip", validators.IpValidator)
-	RegisterValidator("minLength", validators.MinLengthValidator)
-	RegisterValidator("maxLength", validators.MaxLengthValidator)
-	RegisterValidator("gt", validators.GreaterThanValidator)
-	RegisterValidator("lt", validators.LessThanValidator)
-	RegisterValidator("gte", validators.GreaterThanOrEqualValidator)
-	RegisterValidator("lte", validators.LessThanOrEqualValidator)
-	RegisterValidator("range", validators.RangeValidator)
-	RegisterValidator("enum", validators.EnumValidator)
-	RegisterValidator("boolean", validators.BooleanValidator)
-	RegisterValidator("contains", validators.ContainsValidator)
-	RegisterValidator("notContains", validators.NotContainsValidator)
-	RegisterValidator("startsWidth", validators.StartsWidthValidator)
-	RegisterValidator("endsWith", validators.EndsWithValidator)
-
-	// Default transformers
-	RegisterTransformer("uppercase", transformers.ToUpperCase)
-	RegisterTransformer("lowercase", transformers.ToLowerCase)
-	RegisterTransformer("trim", transformers.Trim)
-	RegisterTransformer("trimLeft", transformers.TrimLeft)
-	RegisterTransformer("trimRight", transformers.TrimRight)
-	RegisterTransformer("base64Encode", transformers.Base64Encode)
-	RegisterTransformer("base64Decode", transformers.Base64Decode)
-	RegisterTransformer("urlEncode", transformers.UrlEncode)
-	RegisterTransformer("urlDecode", transformers.UrlDecode)
-}
+// ErrTransformation: Mapping methods return this error when a transformer fails or returns a value that does not fit the field
+var ErrTransformation = errors.New("TransformationError")
 
-// RegisterTransformer adds a transformer function to the registry with a given name.
+// ErrConversion: Mapping methods return this error when a value cannot be converted to the type of the destination field
+var ErrConversion = errors.New("ConversionError")
+
+// RegisterTransformer adds a transformer function to the registry of the default Mapper with a given name.
 func RegisterTransformer(name string, f TransformerFunc) {
-	transformerRegistry[name] = f
+	defaultMapper.RegisterTransformer(name, f)
+}
+
+// RegisterFallibleTransformer adds a transformer function that can fail to the registry of the default Mapper.
+func RegisterFallibleTransformer(name string, f FallibleTransformerFunc) {
+	defaultMapper.RegisterFallibleTransformer(name, f)
+}
+
+// RegisterParamTransformer adds a transformer function that takes an argument to the registry of the default Mapper.
+func RegisterParamTransformer(name string, f ParamTransformerFunc) {
+	defaultMapper.RegisterParamTransformer(name, f)
 }
 
-// RegisterValidator adds a validator function to the registry.
+// RegisterValidator adds a validator function to the registry of the default Mapper.
 func RegisterValidator(name string, f ValidatorFunc) {
-	validatorRegistry[name] = f
+	defaultMapper.RegisterValidator(name, f)
 }
 
-// MapStructs validate, transfor and maps data from source struct to destination struct
+// RegisterCrossFieldValidator adds a validator function that receives the enclosing struct to the registry of the default Mapper.
+func RegisterCrossFieldValidator(name string, f CrossFieldValidatorFunc) {
+	defaultMapper.RegisterCrossFieldValidator(name, f)
+}
+
+// RegisterConverter adds a converter from S to D to the default Mapper, see RegisterConverterOn.
+func RegisterConverter[S, D any](f func(S) (D, error)) {
+	RegisterConverterOn(defaultMapper, f)
+}
+
+// MapStructs validate, transfor and maps data from source struct to destination struct using the default Mapper
 func MapStructs(src, dest interface{}) error {
+	return defaultMapper.MapStructs(src, dest)
+}
 
-	srcValue := reflect.ValueOf(src)
-	destValue := reflect.ValueOf(dest)
-	if !isValidStructPointer(srcValue) || !isValidStructPointer(destValue) {
-		return errors.New("both source and destination must be pointer to a struct")
-	}
+// MapSliceOfStructs iterate over the source slice and map each struct to the destination slice using the default Mapper
+func MapSliceOfStructs(src, dest interface{}) error {
+	return defaultMapper.MapSliceOfStructs(src, dest)
+}
 
-	return mapStructsRecursive(srcValue, destValue)
+// MapJsonStruct decodes a JSON string into the provided struct pointer and applies any necessary validations and transformations using the default Mapper
+func MapJsonStruct(jsonStr string, target interface{}) error {
+	return defaultMapper.MapJsonStruct(jsonStr, target)
+}
+
+// ValidateSingleField validates and transforms a single value using the default Mapper, see Mapper.ValidateSingleField.
+func ValidateSingleField(value interface{}, validatorAndTransformerSpec string) (interface{}, error) {
+	return defaultMapper.ValidateSingleField(value, validatorAndTransformerSpec)
+}
+
+// ValidateStruct validates the struct fields against defined validators using the default Mapper.
+func ValidateStruct(s interface{}) error {
+	return defaultMapper.ValidateStruct(s)
+}
+
+// MapStructs validate, transfor and maps data from source struct to destination struct
+func (m *Mapper) MapStructs(src, dest interface{}) error {
+	return m.MapStructsContext(context.Background(), src, dest)
 }
 
 // MapSliceOfStructs iterate over the source slice and map each struct to the destination slice
-func MapSliceOfStructs(src, dest interface{}) error {
+func (m *Mapper) MapSliceOfStructs(src, dest interface{}) error {
 
 	srcValue := reflect.ValueOf(src)
 	destValue := reflect.ValueOf(dest)
@@ -99,18 +107,16 @@ func MapSliceOfStructs(src, dest interface{}) error {
 		return errors.New("destination must be a pointer to a slice")
 	}
 
+	// Elements on both sides may be structs or pointers to structs
 	srcSlice := srcValue.Elem()
-	destElemType := destValue.Elem().Type().Elem().Elem()
-	destSlice := reflect.MakeSlice(reflect.SliceOf(reflect.PointerTo(destElemType)), srcSlice.Len(), srcSlice.Len())
+	destSlice := reflect.MakeSlice(destValue.Elem().Type(), srcSlice.Len(), srcSlice.Len())
 
-	for i := 0; i < srcSlice.Len(); i++ {
-		srcElem := srcSlice.Index(i)
-		destElem := reflect.New(destElemType)
-		err := MapStructs(srcElem.Interface(), destElem.Interface())
-		if err != nil {
-			return err
-		}
-		destSlice.Index(i).Set(destElem)
+	state := m.newState(context.Background())
+	if err := mapSliceValue(state, srcSlice, destSlice); err != nil {
+		return err
+	}
+	if err := state.result(); err != nil {
+		return err
 	}
 
 	destValue.Elem().Set(destSlice)
@@ -118,48 +124,45 @@ func MapSliceOfStructs(src, dest interface{}) error {
 }
 
 // MapJsonStruct decodes a JSON string into the provided struct pointer and applies any necessary validations and transformations
-func MapJsonStruct(jsonStr string, target interface{}) error {
-	if reflect.ValueOf(target).Kind() != reflect.Ptr {
-		return fmt.Errorf("target must be a pointer to a struct")
-	}
-
-	err := json.Unmarshal([]byte(jsonStr), target)
-	if err != nil {
-		return err
-	}
-
-	return MapStructs(target, target)
+func (m *Mapper) MapJsonStruct(jsonStr string, target interface{}) error {
+	return m.MapJsonStructContext(context.Background(), jsonStr, target)
 }
 
 /**
-    * validatorAndTransformerSpec example : "validators:'arg1,arg2:value'transformers:'transformer1,transformer2'"
+    * validatorAndTransformerSpec example : "validators:'arg1,arg2:value'transformers:'transformer1,transformer2:value'"
+    * use double quotes to pass quoted arguments : "validators:\"enum:'a-b'|'c'\""
 **/
-func ValidateSingleField(value interface{}, validatorAndTransformerSpec string) (interface{}, error) {
-	validatorsStr, transformersStr := parseSingleFieldValidatorAndTransformerSpec(validatorAndTransformerSpec)
+func (m *Mapper) ValidateSingleField(value interface{}, validatorAndTransformerSpec string) (interface{}, error) {
+	validatorsStr, transformersStr, err := parseSingleFieldSpec(validatorAndTransformerSpec)
+	if err != nil {
+		return value, err
+	}
 
 	if len(validatorsStr) > 0 {
-		validators, err := parseFieldValidators(validatorsStr)
+		validators, err := m.parseFieldValidators(validatorsStr)
 		if err != nil {
 			return value, err
 		}
 
-		for _, validator := range validators {
-			if err := validator(value); err != nil {
-				return value, fmt.Errorf("validation failed: %w", ErrValidation)
-			}
+		state := m.newState(context.Background())
+		if !state.runValidators("", value, validators, Parent{}) {
+			return value, state.result()
 		}
 	}
 
 	if len(transformersStr) > 0 {
 
-		transformers, err := parseTransformers(transformersStr)
+		transformers, err := m.parseTransformers(transformersStr)
 		if err != nil {
 			return value, err
 		}
 
-		for _, transformer := range transformers {
-			value = transformer(value)
+		state := m.newState(context.Background())
+		transformed, err := state.runTransformers("", value, transformers)
+		if err != nil {
+			return value, err
 		}
+		value = transformed
 
 	}
 
@@ -167,92 +170,88 @@ func ValidateSingleField(value interface{}, validatorAndTransformerSpec string)
 }
 
 // ValidateStruct validates the struct fields against defined validators.
-func ValidateStruct(s interface{}) error {
-	val := reflect.ValueOf(s)
-	if !isValidStructPointer(val) {
-		return fmt.Errorf("input must be a pointer to a struct")
-	}
-	return validateStructRecursive(val)
+func (m *Mapper) ValidateStruct(s interface{}) error {
+	return m.ValidateStructContext(context.Background(), s)
 }
 
 // validateStructRecursive recursively validates each field of a struct.
-func validateStructRecursive(val reflect.Value) error {
+// Validation failures are collected in the state, only other errors are returned.
+func validateStructRecursive(state *mappingState, path string, val reflect.Value) error {
 	structFields := val.Elem()
 
-	structFieldMap := buildDestinationFieldMap(structFields)
-	transformers, err := findTransformers(structFields)
-	if err != nil {
-		return err
-	}
-
-	validators, err := findValidators(structFields)
+	plan, err := state.mapper.plan(structFields.Type(), structFields.Type())
 	if err != nil {
 		return err
 	}
 
-	for i := 0; i < structFields.NumField(); i++ {
-		field := structFields.Field(i)
-		fieldName := getFieldName(structFields.Type().Field(i), "json")
+	for _, fieldPlan := range plan.fields {
+		if err := state.ctx.Err(); err != nil {
+			return err
+		}
+		field := structFields.Field(fieldPlan.srcIndex)
+		if !field.CanInterface() {
+			continue
+		}
+		fieldPath := joinPath(path, fieldPlan.name)
 
-		if fieldValidators, ok := validators[fieldName]; ok {
-			for _, validator := range fieldValidators {
-				if err := validator(field.Interface()); err != nil {
-					return fmt.Errorf("validation failed for field '%s': %w", fieldName, ErrValidation)
-				}
-			}
+		if !state.runValidators(fieldPath, field.Interface(), fieldPlan.validators, state.parent(structFields)) {
+			continue
 		}
 
-		if structField, ok := structFieldMap[fieldName]; ok && structField.CanSet() {
-			if err := setFieldValue(structField, structField, transformers[fieldName]); err != nil {
+		// Transform the field in place, the map tag only affects the matching with a destination
+		if fieldPlan.name != "" && field.CanSet() {
+			if err := setFieldValue(state, fieldPath, field, field, fieldPlan.transformers); err != nil {
 				return err
 			}
 		}
 	}
+
+	state.runStructHooks(path, structFields)
 	return nil
 }
 
 // mapStructsRecursive recursively maps data from source to destination structs.
-func mapStructsRecursive(srcVal, destVal reflect.Value) error {
+// Validation failures are collected in the state, only other errors are returned.
+func mapStructsRecursive(state *mappingState, path string, srcVal, destVal reflect.Value) error {
 	srcFields := srcVal.Elem()
 	destFields := destVal.Elem()
 
-	// Build destination field map and fetch transformers and validators
-	destMap := buildDestinationFieldMap(destFields)
-	transformers, err := findTransformers(srcFields)
-	if err != nil {
-		return err
-	}
-
-	validators, err := findValidators(srcFields)
+	// Fetch the compiled plan with the matched fields, transformers and validators
+	plan, err := state.mapper.plan(srcFields.Type(), destFields.Type())
 	if err != nil {
 		return err
 	}
 
-	// Iterate through each source field
-	for i := 0; i < srcFields.NumField(); i++ {
-		srcField := srcFields.Field(i)
-		fieldName := getFieldName(srcFields.Type().Field(i), "json")
-
-		if fieldName == "" {
+	// Iterate through each source field, stopping as soon as the context is done
+	for _, fieldPlan := range plan.fields {
+		if err := state.ctx.Err(); err != nil {
+			return err
+		}
+		if fieldPlan.name == "" {
 			continue
 		}
 
-		// Execute validators for the field if any are defined
-		if fieldValidators, ok := validators[fieldName]; ok {
-			for _, validator := range fieldValidators {
-				if err := validator(srcField.Interface()); err != nil {
-					return fmt.Errorf("validation failed for field '%s': %w", fieldName, ErrValidation)
-				}
-			}
+		srcField := srcFields.Field(fieldPlan.srcIndex)
+		fieldPath := joinPath(path, fieldPlan.name)
+
+		// Execute validators for the field if any are defined, an invalid field is not mapped
+		if !state.runValidators(fieldPath, srcField.Interface(), fieldPlan.validators, state.parent(srcFields)) {
+			continue
 		}
 
 		// If a corresponding destination field exists and can be set, apply transformers and set value
-		if destField, ok := destMap[fieldName]; ok && destField.CanSet() {
-			if err := setFieldValue(srcField, destField, transformers[fieldName]); err != nil {
+		if fieldPlan.destIndex < 0 {
+			continue
+		}
+		if destField := destFields.Field(fieldPlan.destIndex); destField.CanSet() {
+			if err := setFieldValue(state, fieldPath, srcField, destField, fieldPlan.transformers); err != nil {
 				return err
 			}
 		}
 	}
+
+	// Run the struct-level hooks of the source once its fields are checked
+	state.runStructHooks(path, srcFields)
 	return nil
 }
 
@@ -261,19 +260,45 @@ func isValidStructPointer(value reflect.Value) bool {
 	return value.Kind() == reflect.Ptr && value.Elem().Kind() == reflect.Struct
 }
 
-// buildDestinationFieldMap creates a map of destination fields keyed by their JSON tag names.
-func buildDestinationFieldMap(destFields reflect.Value) map[string]reflect.Value {
-	fieldMap := make(map[string]reflect.Value)
-	for i := 0; i < destFields.NumField(); i++ {
-		field := destFields.Type().Field(i)
-		fieldName := getFieldName(field, "json")
+// buildDestinationFieldMap creates a map of destination field indexes keyed by their normalized names.
+func (m *Mapper) buildDestinationFieldMap(destType reflect.Type) map[string]int {
+	fieldMap := make(map[string]int)
+	for i := 0; i < destType.NumField(); i++ {
+		fieldName := m.fieldName(destType.Field(i))
 		if fieldName != "" {
-			fieldMap[fieldName] = destFields.Field(i)
+			fieldMap[m.matchKey(fieldName)] = i
 		}
 	}
 	return fieldMap
 }
 
+// fieldName returns the name of a struct field from the tag key of the mapper, falling back to the
+// Go field name when enabled. It returns an empty string for fields that should be ignored.
+func (m *Mapper) fieldName(field reflect.StructField) string {
+	if fieldName := getFieldName(field, m.tagKey); fieldName != "" {
+		return fieldName
+	}
+	if m.fieldNameFallback && field.IsExported() && field.Tag.Get(m.tagKey) != "-" {
+		return field.Name
+	}
+	return ""
+}
+
+// nameSeparatorRemover strips the word separators of snake_case and kebab-case names.
+var nameSeparatorRemover = strings.NewReplacer("_", "", "-", "")
+
+// matchKey normalizes a field name according to the name matching strategy of the mapper.
+func (m *Mapper) matchKey(name string) string {
+	switch m.nameMatching {
+	case MatchCaseInsensitive:
+		return strings.ToLower(name)
+	case MatchNormalized:
+		return nameSeparatorRemover.Replace(strings.ToLower(name))
+	default:
+		return name
+	}
+}
+
 // getFieldName returns the first part of a struct field's tag associated with the provided key or an empty string if not set.
 func getFieldName(field reflect.StructField, key string) string {
 	tag := field.Tag.Get(key)
@@ -283,48 +308,57 @@ func getFieldName(field reflect.StructField, key string) string {
 	return strings.Split(tag, ",")[0]
 }
 
-// findTransformers collects lists of transformers for fields that have a transformer tag specified.
-// It returns an error if any specified transformer does not exist.
-func findTransformers(fields reflect.Value) (map[string][]TransformerFunc, error) {
-	transformers := make(map[string][]TransformerFunc)
-	for i := 0; i < fields.NumField(); i++ {
-		field := fields.Type().Field(i)
-		transformerNames := field.Tag.Get("transformers")
-		if transformerNames != "" {
-			jsonName := getFieldName(field, "json")
-			transformerList, err := parseTransformers(transformerNames)
-			if err != nil {
-				return nil, err
-			}
-			transformers[jsonName] = transformerList
-		}
-	}
-	return transformers, nil
-}
-
-// parseTransformers parses a comma-separated list of transformer names and returns a slice of TransformerFunc.
+// parseTransformers parses a comma-separated list of transformer names with optional arguments, e.g. "trim,truncate:50",
+// and returns the registered transformers. Arguments follow the same grammar as validator arguments, see parseTagEntries,
+// but unquoted arguments are not trimmed, so they may start or end with spaces.
 // It returns an error if any transformer cannot be found in the registry.
-func parseTransformers(names string) ([]TransformerFunc, error) {
-	nameList := strings.Split(names, ",")
-	transformerList := make([]TransformerFunc, 0, len(nameList))
-	for _, name := range nameList {
-		name = strings.TrimSpace(name)
-		if transformer, exists := transformerRegistry[name]; exists {
-			transformerList = append(transformerList, transformer)
-		} else {
-			return nil, fmt.Errorf("transformer '%s' not found", name)
+func (m *Mapper) parseTransformers(names string) ([]fieldTransformer, error) {
+	entries, err := parseTagEntries(names)
+	if err != nil {
+		return nil, err
+	}
+	transformerList := make([]fieldTransformer, 0, len(entries))
+	for _, entry := range entries {
+		transformer, exists := m.lookupTransformer(entry.name)
+		if !exists {
+			return nil, fmt.Errorf("transformer '%s' not found", entry.name)
+		}
+		fn, err := transformer.fn, error(nil)
+		if entry.values != nil && transformer.values != nil {
+			fn, err = transformer.values(entry.values)
+		} else if transformer.checkArg != nil {
+			err = transformer.checkArg(entry.arg)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid argument for transformer '%s': %w", entry.name, err)
 		}
+		transformerList = append(transformerList, fieldTransformer{name: entry.name, arg: entry.arg, fn: fn, fillsMissing: transformer.fillsMissing})
 	}
 	return transformerList, nil
 }
 
-func setFieldValue(srcField, destField reflect.Value, transformers []TransformerFunc) error {
+// setFieldValue converts the source value into the destination field, descending into nested structs and slices.
+func setFieldValue(state *mappingState, path string, srcField, destField reflect.Value, transformers []fieldTransformer) error {
+	// Handle values coming from interfaces, e.g. elements of a map[string]interface{} or []interface{}
+	for srcField.Kind() == reflect.Interface && !srcField.IsNil() {
+		srcField = srcField.Elem()
+	}
+	if !srcField.IsValid() || srcField.Kind() == reflect.Interface {
+		destField.Set(reflect.Zero(destField.Type()))
+		return setMissingValue(state, path, destField.Type(), destField, transformers)
+	}
+
+	// Registered converters take precedence over the built-in rules, first for the types as declared
+	if converted, err := convertField(state, path, srcField, destField, transformers); converted {
+		return err
+	}
+
 	// Handle pointers
 	if srcField.Kind() == reflect.Ptr {
 		if srcField.IsNil() {
-			// Set destination field to nil if source is nil
+			// Set destination field to nil if source is nil, unless a transformer such as default fills it in
 			destField.Set(reflect.Zero(destField.Type()))
-			return nil
+			return setMissingValue(state, path, srcField.Type(), destField, transformers)
 		}
 		srcField = srcField.Elem()
 	}
@@ -336,6 +370,11 @@ func setFieldValue(srcField, destField reflect.Value, transformers []Transformer
 		destField = destField.Elem()
 	}
 
+	// Then for the types the pointers point to
+	if converted, err := convertField(state, path, srcField, destField, transformers); converted {
+		return err
+	}
+
 	// Handle time.Time to time.Time conversion
 	if srcField.Type() == reflect.TypeOf(time.Time{}) && destField.Type() == reflect.TypeOf(time.Time{}) {
 		srcTime := srcField.Interface().(time.Time)
@@ -354,7 +393,12 @@ func setFieldValue(srcField, destField reflect.Value, transformers []Transformer
 	}
 
 	if srcField.Kind() == reflect.Struct && destField.Kind() == reflect.Struct {
-		return mapStructsRecursive(srcField.Addr(), destField.Addr())
+		return mapStructValue(state, path, srcField, destField)
+	}
+
+	// Handle map to struct conversion
+	if srcField.Kind() == reflect.Map && srcField.Type().Key().Kind() == reflect.String && destField.Kind() == reflect.Struct {
+		return mapToStructRecursive(state, path, srcField, destField)
 	}
 
 	if srcField.Kind() == reflect.Slice && destField.Kind() == reflect.Slice {
@@ -362,11 +406,14 @@ func setFieldValue(srcField, destField reflect.Value, transformers []Transformer
 		convertedSlice := reflect.MakeSlice(destField.Type(), srcField.Len(), srcField.Cap())
 
 		for i := 0; i < srcField.Len(); i++ {
+			if err := state.ctx.Err(); err != nil {
+				return err
+			}
 			srcElem := srcField.Index(i)
 			convertedElem := reflect.New(destElemType).Elem()
 
 			// Convert the element recursively or use transformers if needed
-			if err := setFieldValue(srcElem, convertedElem, transformers); err != nil {
+			if err := setFieldValue(state, indexPath(path, i), srcElem, convertedElem, transformers); err != nil {
 				return err
 			}
 
@@ -440,74 +487,49 @@ func setFieldValue(srcField, destField reflect.Value, transformers []Transformer
 	}
 
 	// Apply transformers if any and set the value
-	valueToSet := srcField.Interface()
-	for _, transformer := range transformers {
-		valueToSet = transformer(valueToSet)
+	valueToSet, err := state.runTransformers(path, srcField.Interface(), transformers)
+	if err != nil {
+		return err
 	}
-	destField.Set(reflect.ValueOf(valueToSet))
-	return nil
-}
-
-func findValidators(fields reflect.Value) (map[string][]func(interface{}) error, error) {
-	validators := make(map[string][]func(interface{}) error)
-	for i := 0; i < fields.NumField(); i++ {
-		field := fields.Type().Field(i)
-		validatorSpec := field.Tag.Get("validators")
-		if validatorSpec == "" {
-			continue
-		}
-
-		jsonName := getFieldName(field, "json")
-		fieldValidators, err := parseFieldValidators(validatorSpec)
-		if err != nil {
-			return nil, fmt.Errorf("error parsing validators for field '%s': %v", jsonName, err)
+	if err := assignValue(state, path, reflect.ValueOf(valueToSet), destField); err != nil {
+		// A transformer changed the type of the value, blame the transformation instead of the mapping
+		if len(transformers) > 0 && reflect.TypeOf(valueToSet) != srcField.Type() {
+			return &TransformationError{
+				Path:        path,
+				Transformer: transformers[len(transformers)-1].name,
+				Value:       srcField.Interface(),
+				Err:         fmt.Errorf("returned %T, which is not assignable to %s", valueToSet, destField.Type()),
+			}
 		}
-		validators[jsonName] = fieldValidators
+		return err
 	}
-	return validators, nil
+	return nil
 }
 
-func parseFieldValidators(validatorSpec string) ([]func(interface{}) error, error) {
-	var validators []func(interface{}) error
-	validatorEntries := strings.Split(validatorSpec, ",")
-	for _, entry := range validatorEntries {
-		parts := strings.SplitN(entry, ":", 2)
-		validatorName := strings.TrimSpace(parts[0])
-		arg := ""
-		if len(parts) > 1 {
-			arg = strings.TrimSpace(parts[1])
-		}
-
-		validatorFunc, exists := validatorRegistry[validatorName]
-		if !exists {
-			return nil, fmt.Errorf("validator '%s' not found", validatorName)
-		}
-
-		// Wrap the validator function to include its argument
-		validators = append(validators, func(value interface{}) error {
-			return validatorFunc(value, arg)
-		})
+// convertField maps the source value with the converter registered for the exact source and destination types,
+// after applying the transformers. It reports false when no converter is registered.
+func convertField(state *mappingState, path string, srcField, destField reflect.Value, transformers []fieldTransformer) (bool, error) {
+	if _, ok := state.mapper.lookupConverter(srcField.Type(), destField.Type()); !ok {
+		return false, nil
+	}
+	valueToSet, err := state.runTransformers(path, srcField.Interface(), transformers)
+	if err != nil {
+		return true, err
 	}
-	return validators, nil
+	return true, assignValue(state, path, reflect.ValueOf(valueToSet), destField)
 }
 
-// Helper function to extract values from input based on a given prefix.
-func extractValueFromInput(input, prefix string) string {
-	start := strings.Index(input, prefix)
-	if start == -1 {
-		return ""
+// assignValue sets the value on the destination field, converting it with convertValue when needed.
+// It returns a ConversionError instead of panicking when the value cannot be stored in the field.
+func assignValue(state *mappingState, path string, value, destField reflect.Value) error {
+	if !value.IsValid() {
+		destField.Set(reflect.Zero(destField.Type()))
+		return nil
 	}
-
-	end := strings.Index(input[start+len(prefix):], "'") + start + len(prefix)
-	if end == -1 {
-		return ""
+	converted, err := state.mapper.convertValue(value, destField.Type())
+	if err != nil {
+		return &ConversionError{Path: path, Value: value.Interface(), Type: destField.Type(), Err: err}
 	}
-
-	return input[start+len(prefix) : end]
-}
-
-func parseSingleFieldValidatorAndTransformerSpec(input string) (string, string) {
-	validators := extractValueFromInput(input, "validators:'")
-	transformers := extractValueFromInput(input, "transformers:'")
-	return validators, transformers
+	destField.Set(converted)
+	return nil
 }
@@ -1,17 +1,21 @@
 package xmapper_test
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/dev3mike/go-xmapper"
+	"github.com/dev3mike/go-xmapper/validators"
 )
 
 // Define dummy transformers
@@ -978,3 +982,1691 @@ func TestMapStructsTimeFields(t *testing.T) {
 		t.Errorf("Failed to map UpdatedAt field correctly, got: %v, want: %v", dest.UpdatedAt, later)
 	}
 }
+
+// TestValidateStructCollectsAllErrors checks that every failing field is reported in a single pass.
+func TestValidateStructCollectsAllErrors(t *testing.T) {
+	type Src struct {
+		Email string `json:"email" validators:"email"`
+		Name  string `json:"name" validators:"required"`
+		Phone string `json:"phone" validators:"phone"`
+	}
+
+	src := Src{Email: "not_a_valid_email", Name: "", Phone: "+1234567890"}
+
+	err := xmapper.ValidateStruct(&src)
+	if !errors.Is(err, xmapper.ErrValidation) {
+		t.Fatalf("Expected ErrValidation, got %v", err)
+	}
+
+	var validationErrs xmapper.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		t.Fatalf("Expected ValidationErrors, got %T", err)
+	}
+	if len(validationErrs) != 2 {
+		t.Fatalf("Expected 2 field errors, got %d: %v", len(validationErrs), validationErrs)
+	}
+
+	emailErr := validationErrs[0]
+	if emailErr.Path != "email" || emailErr.Validator != "email" || emailErr.Value != "not_a_valid_email" {
+		t.Errorf("Unexpected email error: %+v", emailErr)
+	}
+	if emailErr.Message != "input is not a valid email address" {
+		t.Errorf("Expected the validator message to be preserved, got '%s'", emailErr.Message)
+	}
+	if validationErrs[1].Path != "name" || validationErrs[1].Validator != "required" {
+		t.Errorf("Unexpected name error: %+v", validationErrs[1])
+	}
+}
+
+// TestMapStructsValidationErrorPaths checks that nested structs and slice elements are reported with their full path.
+func TestMapStructsValidationErrorPaths(t *testing.T) {
+	type Line struct {
+		Text string `json:"text" validators:"maxLength:5"`
+	}
+	type Address struct {
+		City  string `json:"city" validators:"required"`
+		Lines []Line `json:"lines"`
+	}
+	type Src struct {
+		Address Address `json:"address"`
+	}
+	type Dest struct {
+		Address Address `json:"address"`
+	}
+
+	src := Src{Address: Address{Lines: []Line{{Text: "ok"}, {Text: "ok"}, {Text: "too long"}}}}
+	dest := Dest{}
+
+	err := xmapper.MapStructs(&src, &dest)
+
+	var validationErrs xmapper.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		t.Fatalf("Expected ValidationErrors, got %v", err)
+	}
+	if len(validationErrs) != 2 {
+		t.Fatalf("Expected 2 field errors, got %d: %v", len(validationErrs), validationErrs)
+	}
+	if validationErrs[0].Path != "address.city" {
+		t.Errorf("Expected path 'address.city', got '%s'", validationErrs[0].Path)
+	}
+	if validationErrs[1].Path != "address.lines[2].text" || validationErrs[1].Arg != "5" {
+		t.Errorf("Unexpected error for the third line: %+v", validationErrs[1])
+	}
+}
+
+// TestValidateSingleFieldReturnsFieldError checks that ValidateSingleField reports the failing validator.
+func TestValidateSingleFieldReturnsFieldError(t *testing.T) {
+	_, err := xmapper.ValidateSingleField("abc", "validators:'minLength:5'")
+
+	var fieldErr *xmapper.FieldError
+	if !errors.As(err, &fieldErr) {
+		t.Fatalf("Expected a FieldError, got %v", err)
+	}
+	if fieldErr.Validator != "minLength" || fieldErr.Arg != "5" {
+		t.Errorf("Unexpected field error: %+v", fieldErr)
+	}
+	if !errors.Is(err, xmapper.ErrValidation) {
+		t.Errorf("Expected ErrValidation, got %v", err)
+	}
+}
+
+// TestMapperHasIsolatedRegistries checks that validators registered on one Mapper are not visible on another.
+func TestMapperHasIsolatedRegistries(t *testing.T) {
+	type Src struct {
+		Code string `json:"code" validators:"isShortCode"`
+	}
+
+	strict := xmapper.New(xmapper.WithValidator("isShortCode", func(input interface{}, _ string) error {
+		if len(input.(string)) > 3 {
+			return fmt.Errorf("code is too long")
+		}
+		return nil
+	}))
+	lenient := xmapper.New(xmapper.WithValidator("isShortCode", func(interface{}, string) error {
+		return nil
+	}))
+
+	src := Src{Code: "ABCDE"}
+	if err := strict.ValidateStruct(&src); !errors.Is(err, xmapper.ErrValidation) {
+		t.Errorf("Expected ErrValidation from the strict mapper, got %v", err)
+	}
+	if err := lenient.ValidateStruct(&src); err != nil {
+		t.Errorf("Unexpected error from the lenient mapper: %s", err)
+	}
+	if err := xmapper.New().ValidateStruct(&src); err == nil || err.Error() != "error parsing validators for field 'code': validator 'isShortCode' not found" {
+		t.Errorf("Expected a missing validator error from a fresh mapper, got %v", err)
+	}
+}
+
+// TestMapperClone checks that a clone starts with the registries of its parent and can diverge from it.
+func TestMapperClone(t *testing.T) {
+	parent := xmapper.New(xmapper.WithTransformer("exclaim", addExclamation))
+	clone := parent.Clone()
+	clone.RegisterTransformer("exclaim", repeatTwice)
+
+	type Src struct {
+		Message string `json:"message" transformers:"exclaim,uppercase"`
+	}
+
+	parentResult := Src{}
+	if err := parent.MapStructs(&Src{Message: "hi"}, &parentResult); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	cloneResult := Src{}
+	if err := clone.MapStructs(&Src{Message: "hi"}, &cloneResult); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if parentResult.Message != "HI!" || cloneResult.Message != "HI HI" {
+		t.Errorf("Expected 'HI!' and 'HI HI', got '%s' and '%s'", parentResult.Message, cloneResult.Message)
+	}
+}
+
+// TestMapperConcurrentRegistration checks that registering while mapping is safe, run it with -race.
+func TestMapperConcurrentRegistration(t *testing.T) {
+	mapper := xmapper.New()
+
+	type Src struct {
+		Name string `json:"name" validators:"required" transformers:"uppercase"`
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			mapper.RegisterTransformer(fmt.Sprintf("noop%d", i), func(input interface{}) interface{} { return input })
+		}(i)
+		go func() {
+			defer wg.Done()
+			dest := Src{}
+			if err := mapper.MapStructs(&Src{Name: "john"}, &dest); err != nil {
+				t.Errorf("Unexpected error: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// TestMapperPlanInvalidation checks that cached plans pick up validators and transformers registered later.
+func TestMapperPlanInvalidation(t *testing.T) {
+	mapper := xmapper.New(xmapper.WithTransformer("decorate", addExclamation))
+
+	type Src struct {
+		Message string `json:"message" transformers:"decorate"`
+	}
+
+	first := Src{}
+	if err := mapper.MapStructs(&Src{Message: "hi"}, &first); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	mapper.RegisterTransformer("decorate", repeatTwice)
+
+	second := Src{}
+	if err := mapper.MapStructs(&Src{Message: "hi"}, &second); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if first.Message != "hi!" || second.Message != "hi hi" {
+		t.Errorf("Expected 'hi!' and 'hi hi', got '%s' and '%s'", first.Message, second.Message)
+	}
+}
+
+// TestMapGeneric checks that Map accepts values and pointers and returns values and pointers.
+func TestMapGeneric(t *testing.T) {
+	type Src struct {
+		Name string `json:"name" transformers:"uppercase"`
+	}
+	type Dest struct {
+		Name string `json:"name"`
+	}
+
+	dest, err := xmapper.Map[Dest](Src{Name: "john"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if dest.Name != "JOHN" {
+		t.Errorf("Expected 'JOHN', got '%s'", dest.Name)
+	}
+
+	destPtr, err := xmapper.Map[*Dest](&Src{Name: "jane"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if destPtr == nil || destPtr.Name != "JANE" {
+		t.Errorf("Expected 'JANE', got %+v", destPtr)
+	}
+
+	if _, err := xmapper.Map[Dest]("not a struct"); err == nil {
+		t.Error("Expected an error for a non-struct source, got nil")
+	}
+}
+
+// TestMapInto checks the type-checked equivalent of MapStructs.
+func TestMapInto(t *testing.T) {
+	type Src struct {
+		Email string `json:"email" validators:"email"`
+	}
+	type Dest struct {
+		Email string `json:"email"`
+	}
+
+	dest := Dest{}
+	if err := xmapper.MapInto(&Src{Email: "john@example.com"}, &dest); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if dest.Email != "john@example.com" {
+		t.Errorf("Expected 'john@example.com', got '%s'", dest.Email)
+	}
+
+	if err := xmapper.MapInto(&Src{Email: "invalid"}, &dest); !errors.Is(err, xmapper.ErrValidation) {
+		t.Errorf("Expected ErrValidation, got %v", err)
+	}
+
+	mapper := xmapper.New(xmapper.WithTransformer("shout", func(value interface{}) interface{} {
+		return strings.ToUpper(value.(string))
+	}))
+	type Shouted struct {
+		Email string `json:"email" transformers:"shout"`
+	}
+	if err := xmapper.MapIntoWith(mapper, &Shouted{Email: "john@example.com"}, &dest); err != nil || dest.Email != "JOHN@EXAMPLE.COM" {
+		t.Errorf("Expected the transformer of the mapper to be used, got '%s' and %v", dest.Email, err)
+	}
+	if err := xmapper.MapInto(&Shouted{}, &dest); err == nil {
+		t.Errorf("Expected the default mapper to have no 'shout' transformer")
+	}
+}
+
+// TestMapSliceGeneric checks mapping slices of values and pointers in every combination.
+func TestMapSliceGeneric(t *testing.T) {
+	type Src struct {
+		Name string `json:"name" validators:"required"`
+	}
+	type Dest struct {
+		Name string `json:"name"`
+	}
+
+	values, err := xmapper.MapSlice[Src, Dest]([]Src{{Name: "a"}, {Name: "b"}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(values) != 2 || values[1].Name != "b" {
+		t.Errorf("Failed to map slice of values, got %+v", values)
+	}
+
+	pointers, err := xmapper.MapSlice[*Src, *Dest]([]*Src{{Name: "a"}, nil})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(pointers) != 2 || pointers[0].Name != "a" || pointers[1] != nil {
+		t.Errorf("Failed to map slice of pointers, got %+v", pointers)
+	}
+
+	_, err = xmapper.MapSlice[Src, *Dest]([]Src{{Name: "a"}, {Name: ""}})
+	var validationErrs xmapper.ValidationErrors
+	if !errors.As(err, &validationErrs) || validationErrs[0].Path != "[1].name" {
+		t.Errorf("Expected a validation error for '[1].name', got %v", err)
+	}
+}
+
+// TestMapSliceOfStructsToSliceOfValues checks that MapSliceOfStructs accepts a destination of struct values.
+func TestMapSliceOfStructsToSliceOfValues(t *testing.T) {
+	type Tag struct {
+		Name string `json:"name"`
+	}
+
+	src := []Tag{{Name: "go"}, {Name: "programming"}}
+	dest := []Tag{}
+
+	if err := xmapper.MapSliceOfStructs(&src, &dest); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(src, dest) {
+		t.Errorf("Failed to map slice of values, got %+v, want %+v", dest, src)
+	}
+}
+
+// TestMapToStruct checks mapping a decoded JSON document into a struct with validators and transformers.
+func TestMapToStruct(t *testing.T) {
+	type Address struct {
+		City string `json:"city" validators:"required"`
+	}
+	type User struct {
+		Name    string   `json:"name" transformers:"trim,uppercase"`
+		Age     int      `json:"age" validators:"gte:18"`
+		Tags    []string `json:"tags"`
+		Address *Address `json:"address"`
+		Note    string   `json:"note"`
+	}
+
+	var src map[string]interface{}
+	if err := json.Unmarshal([]byte(`{"name":" john ","age":30,"tags":["a","b"],"address":{"city":"Paris"}}`), &src); err != nil {
+		t.Fatalf("Failed to decode JSON: %s", err)
+	}
+
+	user := User{Note: "untouched"}
+	if err := xmapper.MapToStruct(src, &user); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if user.Name != "JOHN" || user.Age != 30 || !reflect.DeepEqual(user.Tags, []string{"a", "b"}) {
+		t.Errorf("Failed to map fields correctly, got: %+v", user)
+	}
+	if user.Address == nil || user.Address.City != "Paris" {
+		t.Errorf("Failed to map nested map, got: %+v", user.Address)
+	}
+	if user.Note != "untouched" {
+		t.Errorf("Missing keys should not alter the field, got '%s'", user.Note)
+	}
+
+	err := xmapper.MapToStruct(map[string]interface{}{"age": 12, "address": map[string]interface{}{}}, &User{})
+	var validationErrs xmapper.ValidationErrors
+	if !errors.As(err, &validationErrs) || len(validationErrs) != 2 {
+		t.Fatalf("Expected 2 validation errors, got %v", err)
+	}
+	if validationErrs[0].Path != "age" || validationErrs[1].Path != "address.city" {
+		t.Errorf("Unexpected validation error paths: %v", validationErrs)
+	}
+}
+
+// TestStructToMap checks converting a struct into a map keyed by JSON names.
+func TestStructToMap(t *testing.T) {
+	type Tag struct {
+		Name string `json:"name"`
+	}
+	type User struct {
+		Email   string    `json:"email" validators:"email" transformers:"uppercase"`
+		Tags    []Tag     `json:"tags"`
+		Manager *Tag      `json:"manager"`
+		Created time.Time `json:"created"`
+		Roles   []string  `json:"roles" transformers:"lowercase"`
+		Scores  []int     `json:"scores" transformers:"toText"`
+		secret  string
+	}
+
+	xmapper.RegisterTransformer("toText", func(input interface{}) interface{} {
+		return fmt.Sprint(input)
+	})
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	src := User{Email: "john@example.com", Tags: []Tag{{Name: "go"}}, Created: created, Roles: []string{"Admin", "DEV"}, Scores: []int{7}, secret: "x"}
+	result, err := xmapper.StructToMap(&src)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	expected := map[string]interface{}{
+		"email":   "JOHN@EXAMPLE.COM",
+		"tags":    []interface{}{map[string]interface{}{"name": "go"}},
+		"manager": nil,
+		"created": created,
+		"roles":   []string{"admin", "dev"},
+		"scores":  []interface{}{"7"},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Failed to convert struct to map, got: %#v, want: %#v", result, expected)
+	}
+
+	if _, err := xmapper.StructToMap(User{Email: "invalid"}); !errors.Is(err, xmapper.ErrValidation) {
+		t.Errorf("Expected ErrValidation, got %v", err)
+	}
+}
+
+// TestMapperFieldNameFallback checks matching fields without tags by their Go field name.
+func TestMapperFieldNameFallback(t *testing.T) {
+	type Src struct {
+		FirstName string
+		LastName  string `json:"-"`
+		Email     string `json:"email" validators:"email"`
+	}
+	type Dest struct {
+		FirstName string
+		LastName  string
+		Email     string `json:"email"`
+	}
+
+	mapper := xmapper.New(xmapper.WithFieldNameFallback())
+
+	dest := Dest{}
+	if err := mapper.MapStructs(&Src{FirstName: "John", LastName: "Doe", Email: "john@example.com"}, &dest); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if dest.FirstName != "John" || dest.LastName != "" || dest.Email != "john@example.com" {
+		t.Errorf("Failed to map by field name, got: %+v", dest)
+	}
+}
+
+// TestMapperNameMatching checks case-insensitive and normalized name matching.
+func TestMapperNameMatching(t *testing.T) {
+	type Src struct {
+		UserID    string `json:"user_id"`
+		FirstName string `json:"FIRSTNAME"`
+	}
+	type Dest struct {
+		UserID    string `json:"userId"`
+		FirstName string `json:"firstName"`
+	}
+
+	dest := Dest{}
+	if err := xmapper.New(xmapper.WithNameMatching(xmapper.MatchCaseInsensitive)).MapStructs(&Src{UserID: "1", FirstName: "John"}, &dest); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if dest.UserID != "" || dest.FirstName != "John" {
+		t.Errorf("Expected only the case-insensitive match, got: %+v", dest)
+	}
+
+	dest = Dest{}
+	if err := xmapper.New(xmapper.WithNameMatching(xmapper.MatchNormalized)).MapStructs(&Src{UserID: "1", FirstName: "John"}, &dest); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if dest.UserID != "1" || dest.FirstName != "John" {
+		t.Errorf("Expected both fields to match, got: %+v", dest)
+	}
+
+	user := Dest{}
+	mapper := xmapper.New(xmapper.WithNameMatching(xmapper.MatchNormalized))
+	if err := mapper.MapToStruct(map[string]interface{}{"user_id": "2"}, &user); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if user.UserID != "2" {
+		t.Errorf("Expected the map key to match, got: %+v", user)
+	}
+}
+
+// TestMapperTagKeyAndRename checks a custom tag key and the map rename tag.
+func TestMapperTagKeyAndRename(t *testing.T) {
+	type Src struct {
+		ID   string `db:"id" map:"userId"`
+		Name string `db:"name" validators:"required"`
+	}
+	type Dest struct {
+		UserID string `db:"userId"`
+		Name   string `db:"name"`
+	}
+
+	mapper := xmapper.New(xmapper.WithTagKey("db"))
+
+	dest := Dest{}
+	if err := mapper.MapStructs(&Src{ID: "42", Name: "John"}, &dest); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if dest.UserID != "42" || dest.Name != "John" {
+		t.Errorf("Failed to map with the db tag, got: %+v", dest)
+	}
+
+	err := mapper.MapStructs(&Src{ID: "42"}, &dest)
+	var validationErrs xmapper.ValidationErrors
+	if !errors.As(err, &validationErrs) || validationErrs[0].Path != "name" {
+		t.Errorf("Expected a validation error for 'name', got %v", err)
+	}
+}
+
+// TestRenamedFieldsOutsideMapping checks that the map tag does not affect validation and map conversion.
+func TestRenamedFieldsOutsideMapping(t *testing.T) {
+	type Item struct {
+		A string `json:"a" map:"b" transformers:"uppercase"`
+		B string `json:"b"`
+		C string `json:"c" map:"zzz" transformers:"trim"`
+	}
+
+	item := Item{A: "a", B: "b", C: " c "}
+	if err := xmapper.ValidateStruct(&item); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if expected := (Item{A: "A", B: "b", C: "c"}); item != expected {
+		t.Errorf("Expected %+v, got %+v", expected, item)
+	}
+
+	data, err := xmapper.StructToMap(&Item{A: "x", B: "y", C: "z"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if expected := map[string]interface{}{"a": "X", "b": "y", "c": "z"}; !reflect.DeepEqual(data, expected) {
+		t.Errorf("Expected %v, got %v", expected, data)
+	}
+
+	var roundTrip Item
+	if err := xmapper.MapToStruct(data, &roundTrip); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if expected := (Item{A: "X", B: "y", C: "z"}); roundTrip != expected {
+		t.Errorf("Expected %+v, got %+v", expected, roundTrip)
+	}
+}
+
+// TestValidateStructDive checks that validators after dive are applied to every element of a slice.
+func TestValidateStructDive(t *testing.T) {
+	type Src struct {
+		Emails []string `json:"emails" validators:"minItems:1,maxItems:3,unique,dive,required,email"`
+	}
+
+	if err := xmapper.ValidateStruct(&Src{Emails: []string{"a@example.com", "b@example.com"}}); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	err := xmapper.ValidateStruct(&Src{Emails: []string{"a@example.com", "invalid", ""}})
+	var validationErrs xmapper.ValidationErrors
+	if !errors.As(err, &validationErrs) || len(validationErrs) != 2 {
+		t.Fatalf("Expected 2 validation errors, got %v", err)
+	}
+	if validationErrs[0].Path != "emails[1]" || validationErrs[0].Validator != "email" {
+		t.Errorf("Unexpected first error: %+v", validationErrs[0])
+	}
+	if validationErrs[1].Path != "emails[2]" || validationErrs[1].Validator != "required" {
+		t.Errorf("Unexpected second error: %+v", validationErrs[1])
+	}
+
+	err = xmapper.ValidateStruct(&Src{Emails: []string{}})
+	if !errors.As(err, &validationErrs) || validationErrs[0].Validator != "minItems" {
+		t.Errorf("Expected a minItems error, got %v", err)
+	}
+}
+
+// TestValidateStructDiveMap checks that dive validates the keys and values of a map.
+func TestValidateStructDiveMap(t *testing.T) {
+	type Src struct {
+		Labels map[string]string `json:"labels" validators:"dive,keys,maxLength:3,endkeys,required"`
+		Matrix [][]int           `json:"matrix" validators:"dive,maxItems:2,dive,lt:10"`
+	}
+
+	src := Src{
+		Labels: map[string]string{"env": "prod", "region": "eu", "app": ""},
+		Matrix: [][]int{{1, 2}, {3, 40}},
+	}
+
+	err := xmapper.ValidateStruct(&src)
+	var validationErrs xmapper.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		t.Fatalf("Expected ValidationErrors, got %v", err)
+	}
+
+	paths := []string{}
+	for _, fieldErr := range validationErrs {
+		paths = append(paths, fieldErr.Path+":"+fieldErr.Validator)
+	}
+	expected := []string{"labels[app]:required", "labels[region]:maxLength", "matrix[1][1]:lt"}
+	if !reflect.DeepEqual(paths, expected) {
+		t.Errorf("Expected errors %v, got %v", expected, paths)
+	}
+	if validationErrs[0].Key || !validationErrs[1].Key || validationErrs[2].Key {
+		t.Errorf("Expected only the maxLength error to be flagged as a key error, got %v", validationErrs)
+	}
+	if !strings.Contains(validationErrs[1].Error(), "key of 'labels[region]'") {
+		t.Errorf("Expected the message to mention the key, got '%s'", validationErrs[1].Error())
+	}
+
+	// A key and its value failing are reported separately under the same path
+	type Codes struct {
+		Codes map[string]string `json:"codes" validators:"dive,keys,maxLength:2,endkeys,maxLength:2"`
+	}
+	err = xmapper.ValidateStruct(&Codes{Codes: map[string]string{"abc": "xyz"}})
+	validationErrs = nil
+	if !errors.As(err, &validationErrs) || len(validationErrs) != 2 {
+		t.Fatalf("Expected two errors, got %v", err)
+	}
+	if validationErrs[0].Path != "codes[abc]" || !validationErrs[0].Key || validationErrs[0].Value != "abc" {
+		t.Errorf("Expected a key error for 'abc', got %+v", validationErrs[0])
+	}
+	if validationErrs[1].Path != "codes[abc]" || validationErrs[1].Key || validationErrs[1].Value != "xyz" {
+		t.Errorf("Expected a value error for 'xyz', got %+v", validationErrs[1])
+	}
+}
+
+// TestValidateStructDiveSyntaxErrors checks that misplaced keys sections are rejected.
+func TestValidateStructDiveSyntaxErrors(t *testing.T) {
+	type MissingEnd struct {
+		Labels map[string]string `json:"labels" validators:"dive,keys,required"`
+	}
+	type MissingDive struct {
+		Labels map[string]string `json:"labels" validators:"keys,required,endkeys"`
+	}
+
+	if err := xmapper.ValidateStruct(&MissingEnd{}); err == nil || errors.Is(err, xmapper.ErrValidation) {
+		t.Errorf("Expected a tag error for a missing endkeys, got %v", err)
+	}
+	if err := xmapper.ValidateStruct(&MissingDive{}); err == nil || errors.Is(err, xmapper.ErrValidation) {
+		t.Errorf("Expected a tag error for keys without dive, got %v", err)
+	}
+}
+
+// TestCrossFieldValidators checks the built-in validators that compare sibling fields.
+func TestCrossFieldValidators(t *testing.T) {
+	type Address struct {
+		Country string `json:"country"`
+	}
+	type Signup struct {
+		Password        string    `json:"password"`
+		PasswordConfirm string    `json:"passwordConfirm" validators:"eqField:password"`
+		Username        string    `json:"username" validators:"neField:password"`
+		StartDate       time.Time `json:"startDate"`
+		EndDate         time.Time `json:"endDate" validators:"gtField:startDate"`
+		MinAge          int       `json:"minAge" validators:"ltField:maxAge"`
+		MaxAge          int       `json:"maxAge"`
+		Phone           string    `json:"phone"`
+		PhoneCountry    string    `json:"phoneCountry" validators:"requiredWith:phone"`
+		Address         Address   `json:"address"`
+		VatNumber       string    `json:"vatNumber" validators:"requiredIf:address.country DE"`
+	}
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	valid := Signup{
+		Password: "secret", PasswordConfirm: "secret", Username: "john",
+		StartDate: start, EndDate: start.Add(time.Hour),
+		MinAge: 18, MaxAge: 30,
+		Phone: "+31600000000", PhoneCountry: "NL",
+		Address: Address{Country: "DE"}, VatNumber: "DE123",
+	}
+	if err := xmapper.ValidateStruct(&valid); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	invalid := Signup{
+		Password: "secret", PasswordConfirm: "other", Username: "secret",
+		StartDate: start, EndDate: start.Add(-time.Hour),
+		MinAge: 40, MaxAge: 30,
+		Phone:   "+31600000000",
+		Address: Address{Country: "DE"},
+	}
+	err := xmapper.ValidateStruct(&invalid)
+	var validationErrs xmapper.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		t.Fatalf("Expected ValidationErrors, got %v", err)
+	}
+
+	failed := []string{}
+	for _, fieldErr := range validationErrs {
+		failed = append(failed, fieldErr.Validator)
+	}
+	expected := []string{"eqField", "neField", "gtField", "ltField", "requiredWith", "requiredIf"}
+	if !reflect.DeepEqual(failed, expected) {
+		t.Errorf("Expected failed validators %v, got %v", expected, failed)
+	}
+
+	// Integers above 2^53 are compared exactly, also between different integer types
+	type Limits struct {
+		Low  int64  `json:"low"`
+		High uint64 `json:"high" validators:"gtField:low"`
+		Copy uint64 `json:"copy" validators:"eqField:low"`
+	}
+	if err := xmapper.ValidateStruct(&Limits{Low: 1 << 60, High: 1<<60 + 1, Copy: 1 << 60}); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+	err = xmapper.ValidateStruct(&Limits{Low: 1 << 60, High: 1 << 60, Copy: 1<<60 + 1})
+	validationErrs = nil
+	if !errors.As(err, &validationErrs) || len(validationErrs) != 2 {
+		t.Fatalf("Expected 2 validation errors, got %v", err)
+	}
+	if validationErrs[0].Path != "high" || validationErrs[0].Validator != "gtField" || validationErrs[1].Path != "copy" || validationErrs[1].Validator != "eqField" {
+		t.Errorf("Expected gtField to fail for high and eqField for copy, got %v", validationErrs)
+	}
+}
+
+// TestCrossFieldValidatorUnknownField checks that referencing a missing field is reported.
+func TestCrossFieldValidatorUnknownField(t *testing.T) {
+	type Src struct {
+		Confirm string `json:"confirm" validators:"eqField:missing"`
+	}
+
+	err := xmapper.ValidateStruct(&Src{})
+	var fieldErr *xmapper.FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Message != "field 'missing' not found" {
+		t.Errorf("Expected a field not found error, got %v", err)
+	}
+}
+
+// TestRegisterCrossFieldValidator checks that custom validators receive the enclosing struct.
+func TestRegisterCrossFieldValidator(t *testing.T) {
+	type Order struct {
+		Quantity int `json:"quantity"`
+		Total    int `json:"total" validators:"totalMatches:10"`
+	}
+
+	mapper := xmapper.New(xmapper.WithCrossFieldValidator("totalMatches", func(value interface{}, arg string, parent xmapper.Parent) error {
+		unitPrice, _ := strconv.Atoi(arg)
+		quantity, ok := parent.Field("quantity")
+		if !ok {
+			return fmt.Errorf("quantity not found")
+		}
+		if _, isOrder := parent.Interface().(Order); !isOrder {
+			return fmt.Errorf("unexpected parent %T", parent.Interface())
+		}
+		if value.(int) != quantity.(int)*unitPrice {
+			return fmt.Errorf("total does not match quantity")
+		}
+		return nil
+	}))
+
+	if err := mapper.ValidateStruct(&Order{Quantity: 3, Total: 30}); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+	if err := mapper.ValidateStruct(&Order{Quantity: 3, Total: 20}); !errors.Is(err, xmapper.ErrValidation) {
+		t.Errorf("Expected ErrValidation, got %v", err)
+	}
+}
+
+type hookedPeriod struct {
+	Start int `json:"start" validators:"gte:0"`
+	End   int `json:"end"`
+}
+
+func (p hookedPeriod) Validate() error {
+	if p.End < p.Start {
+		return errors.New("end must not be before start")
+	}
+	return nil
+}
+
+type hookedBooking struct {
+	Guests  int            `json:"guests"`
+	Periods []hookedPeriod `json:"periods"`
+}
+
+func (b *hookedBooking) ValidateContext(ctx context.Context) error {
+	if ctx == nil {
+		return errors.New("missing context")
+	}
+	if b.Guests > 4 {
+		return xmapper.ValidationErrors{{Path: "guests", Validator: "maxGuests", Message: "too many guests"}}
+	}
+	return nil
+}
+
+// TestStructValidateHooks checks that Validate and ValidateContext are called on nested structs and slice elements.
+func TestStructValidateHooks(t *testing.T) {
+	booking := hookedBooking{
+		Guests:  5,
+		Periods: []hookedPeriod{{Start: 1, End: 2}, {Start: 5, End: 3}},
+	}
+
+	err := xmapper.ValidateStruct(&booking)
+	var validationErrs xmapper.ValidationErrors
+	if !errors.As(err, &validationErrs) || len(validationErrs) != 2 {
+		t.Fatalf("Expected 2 validation errors, got %v", err)
+	}
+	if validationErrs[0].Path != "periods[1]" || validationErrs[0].Validator != "Validate" || validationErrs[0].Message != "end must not be before start" {
+		t.Errorf("Unexpected error for the period: %+v", validationErrs[0])
+	}
+	if validationErrs[1].Path != "guests" || validationErrs[1].Validator != "maxGuests" {
+		t.Errorf("Unexpected error for the booking: %+v", validationErrs[1])
+	}
+
+	dest := hookedBooking{}
+	if err := xmapper.MapStructs(&hookedBooking{Guests: 2, Periods: []hookedPeriod{{Start: 1, End: 2}}}, &dest); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+}
+
+// TestIdentifierValidatorsAreRegistered checks that the identifier validators can be used in struct tags.
+func TestIdentifierValidatorsAreRegistered(t *testing.T) {
+	type Release struct {
+		Id      string `json:"id" validators:"required,uuid:4"`
+		Version string `json:"version" validators:"semver"`
+		Meta    string `json:"meta" validators:"json"`
+		Owner   string `json:"owner" validators:"mongoId"`
+	}
+
+	if err := xmapper.ValidateStruct(&Release{Id: "f47ac10b-58cc-4372-a567-0e02b2c3d479", Version: "2.0.0", Meta: `{}`, Owner: "507f1f77bcf86cd799439011"}); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	err := xmapper.ValidateStruct(&Release{Id: "not-a-uuid", Version: "v2", Meta: "{", Owner: "me"})
+	var validationErrs xmapper.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		t.Fatalf("Expected ValidationErrors, got %v", err)
+	}
+	failures := []string{}
+	for _, fieldErr := range validationErrs {
+		failures = append(failures, fieldErr.Path+":"+fieldErr.Validator)
+	}
+	expected := []string{"id:uuid", "version:semver", "meta:json", "owner:mongoId"}
+	if !reflect.DeepEqual(failures, expected) {
+		t.Errorf("Expected errors %v, got %v", expected, failures)
+	}
+}
+
+// TestNetworkValidatorsAreRegistered checks that the network validators can be used in struct tags.
+func TestNetworkValidatorsAreRegistered(t *testing.T) {
+	type Endpoint struct {
+		Callback string `json:"callback" validators:"required,publicUrl:https"`
+		Listen   string `json:"listen" validators:"hostPort"`
+		Subnet   string `json:"subnet" validators:"cidr"`
+		Port     int    `json:"port" validators:"port"`
+	}
+
+	if err := xmapper.ValidateStruct(&Endpoint{Callback: "https://hooks.example.com/x", Listen: "0.0.0.0:80", Subnet: "10.0.0.0/16", Port: 80}); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	err := xmapper.ValidateStruct(&Endpoint{Callback: "https://127.0.0.1/x", Listen: "0.0.0.0", Subnet: "10.0.0.0", Port: 70000})
+	var validationErrs xmapper.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		t.Fatalf("Expected ValidationErrors, got %v", err)
+	}
+	failures := []string{}
+	for _, fieldErr := range validationErrs {
+		failures = append(failures, fieldErr.Path+":"+fieldErr.Validator)
+	}
+	expected := []string{"callback:publicUrl", "listen:hostPort", "subnet:cidr", "port:port"}
+	if !reflect.DeepEqual(failures, expected) {
+		t.Errorf("Expected errors %v, got %v", expected, failures)
+	}
+}
+
+// TestFinancialValidatorsAreRegistered checks that the financial validators can be used in struct tags.
+func TestFinancialValidatorsAreRegistered(t *testing.T) {
+	type Payment struct {
+		Card     string  `json:"card" validators:"required,creditCard:'visa'|'mastercard'"`
+		Iban     string  `json:"iban" validators:"iban"`
+		Currency string  `json:"currency" validators:"currency"`
+		Amount   float64 `json:"amount" validators:"decimal:'8,2'"`
+	}
+
+	if err := xmapper.ValidateStruct(&Payment{Card: "4111 1111 1111 1111", Iban: "DE89370400440532013000", Currency: "EUR", Amount: 19.99}); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	err := xmapper.ValidateStruct(&Payment{Card: "378282246310005", Iban: "DE00370400440532013000", Currency: "EURO", Amount: 19.999})
+	var validationErrs xmapper.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		t.Fatalf("Expected ValidationErrors, got %v", err)
+	}
+	failures := []string{}
+	for _, fieldErr := range validationErrs {
+		failures = append(failures, fieldErr.Path+":"+fieldErr.Validator)
+	}
+	expected := []string{"card:creditCard", "iban:iban", "currency:currency", "amount:decimal"}
+	if !reflect.DeepEqual(failures, expected) {
+		t.Errorf("Expected errors %v, got %v", expected, failures)
+	}
+}
+
+// TestLocaleValidatorsAreRegistered checks that the locale and geo validators can be used in struct tags.
+func TestLocaleValidatorsAreRegistered(t *testing.T) {
+	type Address struct {
+		Country    string  `json:"country" validators:"required,country"`
+		PostalCode string  `json:"postalCode" validators:"postalCode:DE"`
+		Language   string  `json:"language" validators:"language"`
+		Timezone   string  `json:"timezone" validators:"timezone"`
+		Latitude   float64 `json:"latitude" validators:"latitude"`
+		Longitude  float64 `json:"longitude" validators:"longitude"`
+	}
+
+	if err := xmapper.ValidateStruct(&Address{Country: "DE", PostalCode: "10115", Language: "de-DE", Timezone: "Europe/Berlin", Latitude: 52.52, Longitude: 13.405}); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	err := xmapper.ValidateStruct(&Address{Country: "XX", PostalCode: "1011", Language: "de_DE", Timezone: "Berlin", Latitude: 91, Longitude: -181})
+	var validationErrs xmapper.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		t.Fatalf("Expected ValidationErrors, got %v", err)
+	}
+	failures := []string{}
+	for _, fieldErr := range validationErrs {
+		failures = append(failures, fieldErr.Path+":"+fieldErr.Validator)
+	}
+	expected := []string{"country:country", "postalCode:postalCode", "language:language", "timezone:timezone", "latitude:latitude", "longitude:longitude"}
+	if !reflect.DeepEqual(failures, expected) {
+		t.Errorf("Expected errors %v, got %v", expected, failures)
+	}
+
+	type Unsupported struct {
+		PostalCode string `json:"postalCode" validators:"postalCode:XX"`
+	}
+	err = xmapper.ValidateStruct(&Unsupported{})
+	if err == nil || errors.Is(err, xmapper.ErrValidation) || !strings.Contains(err.Error(), "postal codes of country 'XX' are not supported") {
+		t.Errorf("Expected the unsupported country to be reported when the tags are parsed, got %v", err)
+	}
+}
+
+// TestTextValidatorsAreRegistered checks that the text validators can be used in struct tags.
+func TestTextValidatorsAreRegistered(t *testing.T) {
+	type Profile struct {
+		DisplayName string `json:"displayName" validators:"required,unicodeLetters,maxRunes:5"`
+		Username    string `json:"username" validators:"alphanumeric,lowercase,minRunes:3"`
+		Pin         string `json:"pin" validators:"numeric,exactLength:4"`
+		Code        string `json:"code" validators:"uppercase,ascii,noWhitespace"`
+		Bio         string `json:"bio" validators:"printable"`
+		Initials    string `json:"initials" validators:"alpha"`
+	}
+
+	if err := xmapper.ValidateStruct(&Profile{DisplayName: "مرحبا", Username: "zoe42", Pin: "0042", Code: "ABC-1", Bio: "Hi, I'm Zoë", Initials: "ZS"}); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	err := xmapper.ValidateStruct(&Profile{DisplayName: "Zoë S", Username: "Zo", Pin: "42a", Code: "ab c", Bio: "tab\there", Initials: "Z."})
+	var validationErrs xmapper.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		t.Fatalf("Expected ValidationErrors, got %v", err)
+	}
+	failures := []string{}
+	for _, fieldErr := range validationErrs {
+		failures = append(failures, fieldErr.Path+":"+fieldErr.Validator)
+	}
+	expected := []string{
+		"displayName:unicodeLetters", "username:lowercase", "username:minRunes", "pin:numeric", "pin:exactLength",
+		"code:uppercase", "code:noWhitespace", "bio:printable", "initials:alpha",
+	}
+	if !reflect.DeepEqual(failures, expected) {
+		t.Errorf("Expected errors %v, got %v", expected, failures)
+	}
+}
+
+// TestTimeBoundValidators checks that date and time validators accept layouts and that before, after and between compare against now and fixed dates.
+func TestTimeBoundValidators(t *testing.T) {
+	type Booking struct {
+		BirthDate string    `json:"birthDate" validators:"required,date:02/01/2006"`
+		Birthday  time.Time `json:"birthday" validators:"before:now-18y"`
+		StartsAt  time.Time `json:"startsAt" validators:"required,after:now"`
+		Created   string    `json:"created" validators:"datetime:rfc3339,between:'2020-01-01'|'now'"`
+	}
+
+	valid := Booking{BirthDate: "31/12/1990", Birthday: time.Now().AddDate(-20, 0, 0), StartsAt: time.Now().Add(time.Hour), Created: "2024-05-01T10:00:00Z"}
+	if err := xmapper.ValidateStruct(&valid); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	err := xmapper.ValidateStruct(&Booking{BirthDate: "1990-12-31", Birthday: time.Now().AddDate(-10, 0, 0), StartsAt: time.Now().Add(-time.Hour), Created: "2019-05-01T10:00:00Z"})
+	var validationErrs xmapper.ValidationErrors
+	if !errors.As(err, &validationErrs) || len(validationErrs) != 4 {
+		t.Errorf("Expected 4 validation errors, got %v", err)
+	}
+
+	type InvalidBound struct {
+		Birthday time.Time `json:"birthday" validators:"before:now-18years"`
+	}
+	if err := xmapper.ValidateStruct(&InvalidBound{}); err == nil || !strings.Contains(err.Error(), "invalid time bound 'now-18years'") {
+		t.Errorf("Expected an invalid time bound error, got %v", err)
+	}
+}
+
+// TestPasswordValidatorReportsRule checks that the password validator applies its policy argument and reports the failing rule.
+func TestPasswordValidatorReportsRule(t *testing.T) {
+	type Signup struct {
+		Password string `json:"password" validators:"required,password:'min=10,maxRepeat=2'"`
+	}
+
+	if err := xmapper.ValidateStruct(&Signup{Password: "Tr0ub4dor&3"}); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	err := xmapper.ValidateStruct(&Signup{Password: "Tr0ub4dooor&3"})
+	var passwordErr *validators.PasswordError
+	if !errors.As(err, &passwordErr) || passwordErr.Rule != validators.PasswordRuleMaxRepeat {
+		t.Errorf("Expected a failure of rule maxRepeat, got %v", err)
+	}
+
+	type InvalidOptions struct {
+		Password string `json:"password" validators:"password:'min=ten'"`
+	}
+	if err := xmapper.ValidateStruct(&InvalidOptions{}); err == nil || !strings.Contains(err.Error(), "invalid password option 'min=ten'") {
+		t.Errorf("Expected an invalid option error, got %v", err)
+	}
+}
+
+// TestValidatorExpressions checks that validator tags combine validators with OR, NOT, groups and omitempty.
+func TestValidatorExpressions(t *testing.T) {
+	type Contact struct {
+		Handle   string   `json:"handle" validators:"required,email|phone"`
+		Username string   `json:"username" validators:"!contains:admin,!(startsWidth:_|endsWith:_)"`
+		Code     string   `json:"code" validators:"(minLength:3,alpha)|numeric"`
+		Website  string   `json:"website" validators:"omitempty,url,startsWidth:https"`
+		Tags     []string `json:"tags" validators:"omitempty,minItems:2,dive,alpha|contains:a\\|b"`
+	}
+
+	valid := Contact{Handle: "+14155552671", Username: "zoe", Code: "12", Tags: []string{"go", "a|b"}}
+	if err := xmapper.ValidateStruct(&valid); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	err := xmapper.ValidateStruct(&Contact{Handle: "zoe", Username: "_admin", Code: "ab", Website: "http://example.com", Tags: []string{"x"}})
+	var validationErrs xmapper.ValidationErrors
+	if !errors.As(err, &validationErrs) || len(validationErrs) != 6 {
+		t.Fatalf("Expected 6 validation errors, got %v", err)
+	}
+
+	handleErr := validationErrs[0]
+	var alternativesErr *xmapper.AlternativesError
+	if handleErr.Validator != "email|phone" || !errors.As(handleErr, &alternativesErr) || len(alternativesErr.Branches) != 2 {
+		t.Fatalf("Expected the failure of both alternatives, got %v", handleErr)
+	}
+	if alternativesErr.Branches[0].Validator != "email" || alternativesErr.Branches[1].Validator != "phone" {
+		t.Errorf("Expected the branches to be reported as written, got %+v", alternativesErr.Branches)
+	}
+	if validationErrs[1].Validator != "!contains:admin" || validationErrs[1].Message != "input must not satisfy contains:admin" {
+		t.Errorf("Expected the negated validator to fail, got %v", validationErrs[1])
+	}
+	if validationErrs[2].Validator != "!(startsWidth:_|endsWith:_)" {
+		t.Errorf("Expected the negated group to fail, got %v", validationErrs[2])
+	}
+	expected := "none of the alternatives passed: (minLength:3,alpha): minLength:3: input does not meet the minimum length requirement, minimum length is 3; numeric: input must contain only digits"
+	if validationErrs[3].Message != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, validationErrs[3].Message)
+	}
+	if validationErrs[4].Path != "website" || validationErrs[4].Validator != "startsWidth" {
+		t.Errorf("Expected startsWidth to fail for the website, got %v", validationErrs[4])
+	}
+	if validationErrs[5].Path != "tags" || validationErrs[5].Validator != "minItems" {
+		t.Errorf("Expected minItems to fail for the tags, got %v", validationErrs[5])
+	}
+
+	if _, err := xmapper.ValidateSingleField("+14155552671", "validators:'required,email|phone'"); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+	if _, err := xmapper.ValidateSingleField("root", "validators:'!(enum:root-admin)'"); !errors.As(err, &validationErrs) {
+		t.Errorf("Expected a validation error, got %v", err)
+	}
+
+	// An escaped '|' is part of a single value, it does not split the argument into a list
+	if _, err := xmapper.ValidateSingleField("x", `validators:"contains:x\\|y"`); !errors.As(err, &validationErrs) {
+		t.Errorf("Expected 'x' to fail contains:x\\|y, got %v", err)
+	}
+	if _, err := xmapper.ValidateSingleField("x|y", `validators:"contains:x\\|y"`); err != nil {
+		t.Errorf("Expected 'x|y' to pass contains:x\\|y, got %v", err)
+	}
+	if _, err := xmapper.ValidateSingleField("y", `validators:"enum:x\\|y"`); !errors.As(err, &validationErrs) || validationErrs[0].Arg != `x\|y` {
+		t.Errorf("Expected 'y' to fail enum:x\\|y with the argument as written, got %v", err)
+	}
+}
+
+// TestValidatorExpressionErrors checks that malformed validator expressions are rejected with the offset and reason.
+func TestValidatorExpressionErrors(t *testing.T) {
+	tests := map[string]string{
+		"(email":          "syntax error at offset 0 in '(email': unclosed '('",
+		"email)":          "syntax error at offset 5 in 'email)': unexpected ')'",
+		"email|":          "syntax error at offset 6 in 'email|': expected a name",
+		"!omitempty":      "'omitempty' cannot be combined with '|' or '!'",
+		"email|(dive)":    "'dive' cannot be used inside '|', '!' or parentheses",
+		"email|unknown:x": "validator 'unknown' not found",
+	}
+
+	for spec, expected := range tests {
+		_, err := xmapper.ValidateSingleField("x", "validators:'"+spec+"'")
+		if err == nil || err.Error() != expected {
+			t.Errorf("Expected '%s' for '%s', got '%v'", expected, spec, err)
+		}
+	}
+}
+
+// TestConditionalRequiredValidators checks that requiredWithout and requiredUnless look at the other fields of the struct.
+func TestConditionalRequiredValidators(t *testing.T) {
+	type Account struct {
+		Email   string `json:"email" validators:"requiredWithout:phone"`
+		Phone   string `json:"phone"`
+		Country string `json:"country"`
+		State   string `json:"state" validators:"requiredUnless:country DE"`
+	}
+
+	if err := xmapper.ValidateStruct(&Account{Phone: "+14155552671", Country: "DE"}); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	err := xmapper.ValidateStruct(&Account{Country: "US"})
+	var validationErrs xmapper.ValidationErrors
+	if !errors.As(err, &validationErrs) || len(validationErrs) != 2 {
+		t.Fatalf("Expected 2 validation errors, got %v", err)
+	}
+	if validationErrs[0].Validator != "requiredWithout" || validationErrs[1].Validator != "requiredUnless" {
+		t.Errorf("Expected requiredWithout and requiredUnless to fail, got %v", err)
+	}
+}
+
+// TestNumericValidatorsSupportAllNumberTypes checks that the numeric validators accept every integer and float type, including pointers.
+func TestNumericValidatorsSupportAllNumberTypes(t *testing.T) {
+	type Item struct {
+		Quantity uint32  `json:"quantity" validators:"gt:0,multipleOf:5"`
+		Discount *int8   `json:"discount" validators:"range:0-50"`
+		Weight   float32 `json:"weight" validators:"gte:0.1"`
+		Pin      int     `json:"pin" validators:"len:4"`
+		Stock    uint64  `json:"stock" validators:"lte:18446744073709551614"`
+	}
+
+	discount := int8(10)
+	if err := xmapper.ValidateStruct(&Item{Quantity: 10, Discount: &discount, Weight: 0.1, Pin: 1234, Stock: 5}); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	discount = 60
+	err := xmapper.ValidateStruct(&Item{Quantity: 12, Discount: &discount, Weight: 0.05, Pin: 123, Stock: math.MaxUint64})
+	var validationErrs xmapper.ValidationErrors
+	if !errors.As(err, &validationErrs) || len(validationErrs) != 5 {
+		t.Errorf("Expected 5 validation errors, got %v", err)
+	}
+}
+
+// TestWithNumericStrings checks that numeric strings are only compared as numbers when WithNumericStrings is set.
+func TestWithNumericStrings(t *testing.T) {
+	type Query struct {
+		Page  string `json:"page" validators:"gte:1,lte:100"`
+		Limit string `json:"limit" validators:"multipleOf:10"`
+	}
+
+	if err := xmapper.ValidateStruct(&Query{Page: "2"}); err == nil {
+		t.Errorf("Expected strings to be rejected by default")
+	}
+
+	m := xmapper.New(xmapper.WithNumericStrings())
+	if err := m.ValidateStruct(&Query{Page: "2", Limit: "20"}); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+	err := m.ValidateStruct(&Query{Page: "101", Limit: "ten"})
+	var validationErrs xmapper.ValidationErrors
+	if !errors.As(err, &validationErrs) || len(validationErrs) != 2 || validationErrs[1].Message != "input must be a number" {
+		t.Errorf("Expected 2 validation errors, got %v", err)
+	}
+}
+
+// TestTypeConversions checks that mapping converts between numeric, string, bool and named types.
+func TestTypeConversions(t *testing.T) {
+	type Status string
+	type Source struct {
+		Count   int     `json:"count"`
+		Ratio   float32 `json:"ratio"`
+		Age     string  `json:"age"`
+		Active  string  `json:"active"`
+		Status  Status  `json:"status"`
+		Version int     `json:"version"`
+	}
+	type Target struct {
+		Count   int64   `json:"count"`
+		Ratio   float64 `json:"ratio"`
+		Age     int     `json:"age"`
+		Active  bool    `json:"active"`
+		Status  string  `json:"status"`
+		Version string  `json:"version"`
+	}
+
+	var dest Target
+	src := Source{Count: 42, Ratio: 0.5, Age: " 30 ", Active: "true", Status: "open", Version: 2}
+	if err := xmapper.MapStructs(&src, &dest); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected := Target{Count: 42, Ratio: 0.5, Age: 30, Active: true, Status: "open", Version: "2"}
+	if dest != expected {
+		t.Errorf("Expected %+v, got %+v", expected, dest)
+	}
+
+	type Small struct {
+		Count int8 `json:"count"`
+	}
+	err := xmapper.MapStructs(&Source{Count: 300}, &Small{})
+	var conversionErr *xmapper.ConversionError
+	if !errors.As(err, &conversionErr) || !errors.Is(err, xmapper.ErrConversion) || conversionErr.Path != "count" {
+		t.Errorf("Expected a conversion error for the overflow, got %v", err)
+	}
+
+	type Invalid struct {
+		Age int `json:"age"`
+	}
+	if err := xmapper.MapStructs(&Source{Age: "thirty"}, &Invalid{}); !errors.Is(err, xmapper.ErrConversion) {
+		t.Errorf("Expected a conversion error for the invalid number, got %v", err)
+	}
+
+	type Price struct {
+		Amount float64 `json:"amount"`
+	}
+	type Cents struct {
+		Amount int `json:"amount"`
+	}
+	if err := xmapper.MapStructs(&Price{Amount: 2.5}, &Cents{}); !errors.Is(err, xmapper.ErrConversion) {
+		t.Errorf("Expected fractional floats to be rejected by default, got %v", err)
+	}
+
+	var rounded, truncated Cents
+	if err := xmapper.New(xmapper.WithFloatConversion(xmapper.FloatRound)).MapStructs(&Price{Amount: 2.5}, &rounded); err != nil || rounded.Amount != 3 {
+		t.Errorf("Expected 2.5 to be rounded to 3, got %d (%v)", rounded.Amount, err)
+	}
+	if err := xmapper.New(xmapper.WithFloatConversion(xmapper.FloatTruncate)).MapStructs(&Price{Amount: -2.7}, &truncated); err != nil || truncated.Amount != -2 {
+		t.Errorf("Expected -2.7 to be truncated to -2, got %d (%v)", truncated.Amount, err)
+	}
+}
+
+// TestConverters checks that registered converters are used for fields, pointers and slices when mapping.
+func TestConverters(t *testing.T) {
+	type Money struct {
+		Units int64
+		Cents int64
+	}
+	type ID [4]byte
+	type Line struct {
+		Price Money `json:"price"`
+	}
+	type LineDto struct {
+		Price int64 `json:"price"`
+	}
+	type Order struct {
+		ID       ID      `json:"id"`
+		Total    *Money  `json:"total"`
+		Shipping Money   `json:"shipping"`
+		Refunds  []Money `json:"refunds"`
+		Lines    []Line  `json:"lines"`
+	}
+	type OrderDto struct {
+		ID       string    `json:"id"`
+		Total    int64     `json:"total"`
+		Shipping int64     `json:"shipping"`
+		Refunds  []int64   `json:"refunds"`
+		Lines    []LineDto `json:"lines"`
+	}
+
+	toCents := func(m Money) (int64, error) {
+		if m.Cents < 0 || m.Cents > 99 {
+			return 0, fmt.Errorf("invalid cents %d", m.Cents)
+		}
+		return m.Units*100 + m.Cents, nil
+	}
+	mapper := xmapper.New(
+		xmapper.WithConverter(toCents),
+		xmapper.WithConverter(func(id ID) (string, error) {
+			return fmt.Sprintf("%x", id[:]), nil
+		}),
+	)
+
+	src := Order{
+		ID:       ID{0xde, 0xad, 0xbe, 0xef},
+		Total:    &Money{Units: 12, Cents: 50},
+		Shipping: Money{Units: 4},
+		Refunds:  []Money{{Units: 1}, {Cents: 5}},
+		Lines:    []Line{{Price: Money{Units: 8, Cents: 50}}},
+	}
+	var dest OrderDto
+	if err := mapper.MapStructs(&src, &dest); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if dest.ID != "deadbeef" || dest.Total != 1250 || dest.Shipping != 400 ||
+		!reflect.DeepEqual(dest.Refunds, []int64{100, 5}) || !reflect.DeepEqual(dest.Lines, []LineDto{{Price: 850}}) {
+		t.Errorf("Unexpected result %+v", dest)
+	}
+
+	src.Refunds = []Money{{Cents: 250}}
+	err := mapper.MapStructs(&src, &OrderDto{})
+	var conversionErr *xmapper.ConversionError
+	if !errors.As(err, &conversionErr) || conversionErr.Path != "refunds[0]" || conversionErr.Error() != "cannot map field 'refunds[0]': invalid cents 250" {
+		t.Errorf("Expected the converter error for refunds[0], got %v", err)
+	}
+
+	// Converters are registered per Mapper
+	if err := xmapper.MapStructs(&Line{}, &LineDto{}); err == nil {
+		t.Errorf("Expected the default mapper to have no converter for Money")
+	}
+	xmapper.RegisterConverter(toCents)
+	var line LineDto
+	if err := xmapper.MapStructs(&Line{Price: Money{Units: 3}}, &line); err != nil || line.Price != 300 {
+		t.Errorf("Expected the converter of the default mapper to be used, got %d (%v)", line.Price, err)
+	}
+}
+
+// TestPatternValidator checks that pattern matches a regular expression and rejects an invalid one when the tag is parsed.
+func TestPatternValidator(t *testing.T) {
+	type Product struct {
+		Sku string `json:"sku" validators:"required,pattern:'^[A-Z]{3}-\\d{4}$'"`
+	}
+
+	if err := xmapper.ValidateStruct(&Product{Sku: "ABC-1234"}); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+	err := xmapper.ValidateStruct(&Product{Sku: "abc-1234"})
+	var validationErrs xmapper.ValidationErrors
+	if !errors.As(err, &validationErrs) || len(validationErrs) != 1 || validationErrs[0].Validator != "pattern" {
+		t.Errorf("Expected a pattern validation error, got %v", err)
+	}
+
+	type Broken struct {
+		Code string `json:"code" validators:"pattern:'[a-z'"`
+	}
+	err = xmapper.ValidateStruct(&Broken{})
+	if err == nil || errors.Is(err, xmapper.ErrValidation) || !strings.Contains(err.Error(), "invalid pattern '[a-z'") {
+		t.Errorf("Expected the invalid pattern to be reported when the tags are parsed, got %v", err)
+	}
+
+	if _, err := xmapper.ValidateSingleField("42", `validators:"pattern:'^\d+$'"`); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+}
+
+// TestTagGrammar checks that tag arguments support quoting, escapes and lists of values.
+func TestTagGrammar(t *testing.T) {
+	type Task struct {
+		Title    string `json:"title" validators:"contains:'foo,bar'"`
+		Status   string `json:"status" validators:"enum:\"in-progress\"|'done'"`
+		Priority int    `json:"priority" validators:"range:'-10'|'10'"`
+		Tags     string `json:"tags" validators:"notContains:a\\,b" transformers:"suffix:\\,x"`
+		Quote    string `json:"quote" validators:"startsWidth:\"it's\""`
+	}
+
+	valid := Task{Title: "foo,bar!", Status: "in-progress", Priority: -5, Tags: "c", Quote: "it's fine"}
+	dest := Task{}
+	if err := xmapper.MapStructs(&valid, &dest); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if dest.Tags != "c,x" {
+		t.Errorf("Expected the escaped comma to be part of the argument, got '%s'", dest.Tags)
+	}
+
+	invalid := Task{Title: "a bar", Status: "in", Priority: -11, Tags: "xb", Quote: "its"}
+	err := xmapper.ValidateStruct(&invalid)
+	var validationErrs xmapper.ValidationErrors
+	if !errors.As(err, &validationErrs) || len(validationErrs) != 5 {
+		t.Fatalf("Expected 5 validation errors, got %v", err)
+	}
+	if validationErrs[1].Arg != `"in-progress"|'done'` {
+		t.Errorf("Expected the argument as written in the tag, got '%s'", validationErrs[1].Arg)
+	}
+
+	type Broken struct {
+		Name string `json:"name" validators:"required,enum:'a'b"`
+	}
+	err = xmapper.ValidateStruct(&Broken{Name: "a"})
+	var syntaxErr *xmapper.SyntaxError
+	if !errors.As(err, &syntaxErr) || syntaxErr.Offset != 17 || syntaxErr.Input != "required,enum:'a'b" {
+		t.Errorf("Expected a syntax error at offset 17, got %v", err)
+	}
+
+	if _, err := xmapper.ValidateSingleField("x", "validators:'required,enum:'a'"); !errors.As(err, &syntaxErr) {
+		t.Errorf("Expected a syntax error for an unterminated spec, got %v", err)
+	}
+	if _, err := xmapper.ValidateSingleField("x", "validator:'required'"); !errors.As(err, &syntaxErr) || syntaxErr.Offset != 0 {
+		t.Errorf("Expected a syntax error for an unknown key, got %v", err)
+	}
+
+	result, err := xmapper.ValidateSingleField("done", `validators:"enum:'in-progress'|'done'" transformers:'prefix:it\'s '`)
+	if err != nil || result != "it's done" {
+		t.Errorf("Expected \"it's done\", got '%v' and %v", result, err)
+	}
+	if _, err := xmapper.ValidateSingleField("in", `validators:"enum:'in-progress'|'done'"`); !errors.Is(err, xmapper.ErrValidation) {
+		t.Errorf("Expected a validation error, got %v", err)
+	}
+
+	// Quoted arguments are literal, the separators of the unquoted syntax do not split them
+	literal := []struct {
+		value string
+		spec  string
+		valid bool
+	}{
+		{"a", `validators:"enum:'a-b'"`, false},
+		{"a-b", `validators:"enum:'a-b'"`, true},
+		{"foo only", `validators:"contains:'foo,bar'"`, false},
+		{"foo,bar", `validators:"contains:'foo,bar'"`, true},
+		{"y", `validators:"notContains:'x,y'"`, true},
+		{"x,y", `validators:"notContains:'x,y'"`, false},
+		{"xyz", `validators:"contains:'a|y'"`, false},
+		{"a|y", `validators:"contains:'a|y'"`, true},
+		{`a\b`, `validators:"enum:'a\\\\b'"`, true},
+		{"a", "validators:'enum:a-b'", true},
+		{"y", "validators:'notContains:x\\,y'", false},
+	}
+	for _, tc := range literal {
+		_, err := xmapper.ValidateSingleField(tc.value, tc.spec)
+		if tc.valid && err != nil {
+			t.Errorf("Expected '%s' to pass %s, got %v", tc.value, tc.spec, err)
+		}
+		if !tc.valid && !errors.Is(err, xmapper.ErrValidation) {
+			t.Errorf("Expected '%s' to fail %s, got %v", tc.value, tc.spec, err)
+		}
+	}
+
+	// Custom validators receive the decoded argument, several quoted values are joined for validators.SplitArgs
+	var received []string
+	m := xmapper.New(xmapper.WithValidator("record", func(_ interface{}, arg string) error {
+		received = append(received, arg)
+		return nil
+	}))
+	type Recorded struct {
+		Single string `json:"single" validators:"record:'a-b'"`
+		Quote  string `json:"quote" validators:"record:\"it's\""`
+		List   string `json:"list" validators:"record:'a|b'|'c'"`
+		Plain  string `json:"plain" validators:"record: x\\,y "`
+	}
+	if err := m.ValidateStruct(&Recorded{}); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected := []string{"a-b", "it's", `a\|b|c`, "x,y"}
+	if !reflect.DeepEqual(received, expected) {
+		t.Errorf("Expected the arguments %q, got %q", expected, received)
+	}
+	if values := validators.SplitArgs(received[2]); !reflect.DeepEqual(values, []string{"a|b", "c"}) {
+		t.Errorf("Expected the list to split into its values, got %q", values)
+	}
+}
+
+// TestParamTransformers checks that transformers accept arguments and that the parameterized built-in transformers apply them.
+func TestParamTransformers(t *testing.T) {
+	type Order struct {
+		Code     string  `json:"code" transformers:"trim,padLeft:6:0,prefix:ORD-"`
+		Note     string  `json:"note" transformers:"truncate:5,suffix:..."`
+		Country  string  `json:"country" transformers:"default:DE"`
+		Quantity int     `json:"quantity" transformers:"default:1"`
+		Phone    string  `json:"phone" transformers:"regexReplace:[^0-9+]:,replace:+:00"`
+		Label    string  `json:"label" transformers:"padRight:4"`
+		Price    float64 `json:"price" transformers:"default:9.5"`
+	}
+
+	dest := Order{}
+	src := Order{Code: " 42 ", Note: "Leave at the door", Phone: "+49 (30) 1234", Label: "ab"}
+	if err := xmapper.MapStructs(&src, &dest); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected := Order{Code: "ORD-000042", Note: "Leave...", Country: "DE", Quantity: 1, Phone: "0049301234", Label: "ab  ", Price: 9.5}
+	if dest != expected {
+		t.Errorf("Expected %+v, got %+v", expected, dest)
+	}
+
+	type Shipping struct {
+		Country *string `json:"country" transformers:"default:de,uppercase"`
+		Note    *string `json:"note" transformers:"trim"`
+		Days    int     `json:"days" transformers:"default:3"`
+	}
+	shipping := Shipping{}
+	if err := xmapper.MapStructs(&Shipping{}, &shipping); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if shipping.Country == nil || *shipping.Country != "DE" || shipping.Note != nil {
+		t.Errorf("Expected the default for a nil pointer and nil without one, got %v and %v", shipping.Country, shipping.Note)
+	}
+	shipping = Shipping{}
+	if err := xmapper.MapToStruct(map[string]interface{}{}, &shipping); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if shipping.Country == nil || *shipping.Country != "DE" || shipping.Note != nil || shipping.Days != 3 {
+		t.Errorf("Expected the defaults for missing keys, got %+v", shipping)
+	}
+
+	type Invalid struct {
+		Name string `json:"name" transformers:"truncate:many"`
+	}
+	err := xmapper.MapStructs(&Invalid{Name: "John"}, &Invalid{})
+	var transformErr *xmapper.TransformationError
+	if !errors.As(err, &transformErr) || transformErr.Transformer != "truncate" {
+		t.Errorf("Expected a transformation error for an invalid argument, got %v", err)
+	}
+
+	result, err := xmapper.ValidateSingleField("john", "transformers:'uppercase,prefix:Mr. '")
+	if err != nil || result != "Mr. JOHN" {
+		t.Errorf("Expected 'Mr. JOHN', got '%v' and %v", result, err)
+	}
+
+	mapper := xmapper.New(xmapper.WithParamTransformer("repeat", func(value interface{}, arg string) (interface{}, error) {
+		count, err := strconv.Atoi(arg)
+		if err != nil {
+			return value, err
+		}
+		return strings.Repeat(value.(string), count), nil
+	}))
+	if result, err := mapper.ValidateSingleField("ab", "transformers:'repeat:3'"); err != nil || result != "ababab" {
+		t.Errorf("Expected 'ababab', got '%v' and %v", result, err)
+	}
+
+	// Arguments whose values contain a colon are written as a list of quoted values
+	type Times struct {
+		Clock    string `json:"clock" transformers:"replace:':'|'-'"`
+		Repeated string `json:"repeated" transformers:"regexReplace:'(?:ab)+'|'X'"`
+		Duration string `json:"duration" transformers:"regexReplace:'(\\d+):(\\d+)'|'${1}h${2}'"`
+		Padded   string `json:"padded" transformers:"padLeft:'5'|':'"`
+	}
+	times := Times{}
+	if err := xmapper.MapStructs(&Times{Clock: "10:30", Repeated: "ababc", Duration: "2:15", Padded: "7"}, &times); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if expected := (Times{Clock: "10-30", Repeated: "Xc", Duration: "2h15", Padded: "::::7"}); times != expected {
+		t.Errorf("Expected %+v, got %+v", expected, times)
+	}
+
+	type BrokenPattern struct {
+		Name string `json:"name" transformers:"regexReplace:'[a-'|'x'"`
+	}
+	err = xmapper.ValidateStruct(&BrokenPattern{})
+	if err == nil || errors.As(err, &transformErr) || !strings.Contains(err.Error(), "invalid pattern '[a-'") {
+		t.Errorf("Expected the invalid pattern to be reported when the tags are parsed, got %v", err)
+	}
+}
+
+// TestFallibleTransformers checks that failing transformers, and transformers that change the type, are reported as a TransformationError.
+func TestFallibleTransformers(t *testing.T) {
+	type Payload struct {
+		Data  string `json:"data" transformers:"base64Decode"`
+		Count string `json:"count" transformers:"toLengths"`
+	}
+	type Target struct {
+		Data  string `json:"data"`
+		Count string `json:"count"`
+	}
+
+	mapper := xmapper.New(xmapper.WithTransformer("toLengths", func(input interface{}) interface{} {
+		return []int{len(input.(string))}
+	}))
+
+	err := mapper.MapStructs(&Payload{Data: "not base64!"}, &Target{})
+	var transformErr *xmapper.TransformationError
+	if !errors.Is(err, xmapper.ErrTransformation) || !errors.As(err, &transformErr) {
+		t.Fatalf("Expected a transformation error, got %v", err)
+	}
+	if transformErr.Path != "data" || transformErr.Transformer != "base64Decode" || transformErr.Value != "not base64!" {
+		t.Errorf("Unexpected transformation error: %+v", transformErr)
+	}
+	if errors.Is(err, xmapper.ErrValidation) {
+		t.Errorf("Expected a transformation error not to be a validation error")
+	}
+
+	err = mapper.MapStructs(&Payload{Data: "aGVsbG8=", Count: "abc"}, &Target{})
+	if !errors.As(err, &transformErr) || transformErr.Path != "count" || transformErr.Transformer != "toLengths" {
+		t.Errorf("Expected a transformation error for a transformer changing the type, got %v", err)
+	}
+
+	dest := Target{}
+	mapper.RegisterFallibleTransformer("toLengths", func(input interface{}) (interface{}, error) {
+		return input, nil
+	})
+	if err := mapper.MapStructs(&Payload{Data: "aGVsbG8=", Count: "3"}, &dest); err != nil || dest.Data != "hello" {
+		t.Errorf("Expected the value to be decoded, got '%s' and %v", dest.Data, err)
+	}
+
+	if _, err := mapper.ValidateSingleField("%zz", "transformers:'urlDecode'"); !errors.Is(err, xmapper.ErrTransformation) {
+		t.Errorf("Expected a transformation error, got %v", err)
+	}
+}
+
+type ctxKey struct{}
+
+// TestContextAwareValidatorsAndTransformers checks that context-aware validators and transformers receive the context of the call.
+func TestContextAwareValidatorsAndTransformers(t *testing.T) {
+	type Account struct {
+		Username string   `json:"username" validators:"availableUsername" transformers:"tenantPrefix:acme"`
+		Tags     []string `json:"tags"`
+	}
+
+	mapper := xmapper.New(
+		xmapper.WithValidatorContext("availableUsername", func(ctx context.Context, value interface{}, _ string) error {
+			if taken, _ := ctx.Value(ctxKey{}).(string); value == taken {
+				return errors.New("username is taken")
+			}
+			return nil
+		}),
+		xmapper.WithTransformerContext("tenantPrefix", func(ctx context.Context, value interface{}, arg string) (interface{}, error) {
+			return arg + "/" + value.(string), nil
+		}),
+	)
+	ctx := context.WithValue(context.Background(), ctxKey{}, "john")
+
+	dest := Account{}
+	if err := mapper.MapStructsContext(ctx, &Account{Username: "jane"}, &dest); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if dest.Username != "acme/jane" {
+		t.Errorf("Expected the context-aware transformer to be applied, got '%s'", dest.Username)
+	}
+
+	err := mapper.ValidateStructContext(ctx, &Account{Username: "john"})
+	var validationErrs xmapper.ValidationErrors
+	if !errors.As(err, &validationErrs) || len(validationErrs) != 1 || validationErrs[0].Validator != "availableUsername" {
+		t.Errorf("Expected the context-aware validator to fail, got %v", err)
+	}
+
+	if err := mapper.MapJsonStructContext(ctx, `{"username":"john"}`, &Account{}); !errors.Is(err, xmapper.ErrValidation) {
+		t.Errorf("Expected a validation error, got %v", err)
+	}
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = mapper.MapStructsContext(cancelled, &Account{Username: "jane", Tags: []string{"a", "b"}}, &Account{})
+	if !errors.Is(err, context.Canceled) || errors.Is(err, xmapper.ErrValidation) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if err := mapper.ValidateStructContext(cancelled, &Account{Username: "john"}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+// TestContextIsPassedToValidateContextHook checks that the ValidateContext hook receives the context and that its cancellation is reported.
+func TestContextIsPassedToValidateContextHook(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	target := &ctxHooked{cancel: cancel}
+	err := xmapper.ValidateStructContext(ctx, target)
+	if target.ctx != ctx {
+		t.Errorf("Expected ValidateContext to receive the context of the call")
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled after the hook cancelled the context, got %v", err)
+	}
+}
+
+type ctxHooked struct {
+	Name   string `json:"name"`
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func (c *ctxHooked) ValidateContext(ctx context.Context) error {
+	c.ctx = ctx
+	c.cancel()
+	return nil
+}
+
+type benchAddress struct {
+	Street string `json:"street" validators:"required" transformers:"trim"`
+	City   string `json:"city" validators:"required,maxLength:50"`
+	Zip    string `json:"zip"`
+}
+
+type benchUser struct {
+	Email   string       `json:"email" validators:"required,email" transformers:"lowercase"`
+	Name    string       `json:"name" validators:"required,minLength:2,maxLength:50" transformers:"trim"`
+	Age     int          `json:"age" validators:"gte:18"`
+	Tags    []string     `json:"tags"`
+	Address benchAddress `json:"address"`
+}
+
+type benchUserDto struct {
+	Email   string       `json:"email"`
+	Name    string       `json:"name"`
+	Age     int          `json:"age"`
+	Tags    []string     `json:"tags"`
+	Address benchAddress `json:"address"`
+}
+
+func newBenchUser() benchUser {
+	return benchUser{
+		Email:   "john.doe@example.com",
+		Name:    " John Doe ",
+		Age:     30,
+		Tags:    []string{"go", "mapper"},
+		Address: benchAddress{Street: " Main Street 1 ", City: "Amsterdam", Zip: "1000AA"},
+	}
+}
+
+// BenchmarkMapStructs measures mapping a struct with nested structs and slices.
+func BenchmarkMapStructs(b *testing.B) {
+	src := newBenchUser()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		dest := benchUserDto{}
+		if err := xmapper.MapStructs(&src, &dest); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkValidateStruct measures validating a struct without mapping it.
+func BenchmarkValidateStruct(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		src := newBenchUser()
+		if err := xmapper.ValidateStruct(&src); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkMapSliceOfStructs measures mapping a slice of 1000 struct pointers.
+func BenchmarkMapSliceOfStructs(b *testing.B) {
+	src := make([]*benchUser, 1000)
+	for i := range src {
+		user := newBenchUser()
+		src[i] = &user
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dest := []*benchUserDto{}
+		if err := xmapper.MapSliceOfStructs(&src, &dest); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,504 @@
+package xmapper
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/dev3mike/go-xmapper/transformers"
+	"github.com/dev3mike/go-xmapper/validators"
+)
+
+// Mapper validates, transforms and maps structs using its own validator and transformer registries.
+// A Mapper is safe for concurrent use, including registering validators and transformers while mapping.
+type Mapper struct {
+	mu sync.RWMutex
+
+	// transformers holds registered transformer functions keyed by their name.
+	// Plain transformers are wrapped so every transformer receives the context and can fail.
+	transformers map[string]registeredTransformer
+
+	// validators holds registered validator functions keyed by their name.
+	// Plain validators are wrapped so every validator receives the context and the enclosing struct.
+	validators map[string]registeredValidator
+
+	// plans caches compiled plans per source and destination type, it is reset whenever a registry changes.
+	plans map[planKey]*structPlan
+
+	// generation is incremented on every registry change, so plans compiled concurrently are not cached.
+	generation uint64
+
+	// tagKey is the struct tag that holds the name of a field, "json" by default.
+	tagKey string
+
+	// fieldNameFallback makes fields without a name tag use their Go field name.
+	fieldNameFallback bool
+
+	// nameMatching decides how source and destination field names are compared.
+	nameMatching NameMatching
+
+	// floatConversion decides how floats with a fractional part are mapped to integer fields.
+	floatConversion FloatConversion
+
+	// converters holds registered type converters keyed by their exact source and destination types.
+	converters map[converterKey]convertFunc
+}
+
+// registeredValidator is a validator stored in the registry of a Mapper.
+type registeredValidator struct {
+	fn validateFunc
+
+	// values builds the validator from the values of a quoted argument when it needs them exactly, e.g. enum:'a-b'|'c'.
+	// It is used instead of fn and checkArg for quoted arguments and is nil for most validators.
+	values func(values []string) (validateFunc, error)
+
+	// checkArg validates the argument once when a tag is parsed, e.g. to compile a pattern. It is nil for most validators.
+	checkArg func(arg string) error
+}
+
+// registeredTransformer is a transformer stored in the registry of a Mapper.
+type registeredTransformer struct {
+	fn transformFunc
+
+	// values builds the transformer from the values of a quoted argument when it needs them exactly, e.g. replace:':'|'-'.
+	// It is used instead of fn and checkArg for quoted arguments and is nil for most transformers.
+	values func(values []string) (transformFunc, error)
+
+	// checkArg validates the argument once when a tag is parsed, e.g. to compile a pattern. It is nil for most transformers.
+	checkArg func(arg string) error
+
+	// fillsMissing makes the transformers of a field also run when the source has no value, e.g. a nil pointer or a
+	// missing map key, so default can fill it in.
+	fillsMissing bool
+}
+
+// NameMatching decides how source and destination field names are compared.
+type NameMatching int
+
+const (
+	// MatchExact matches names that are exactly equal. This is the default.
+	MatchExact NameMatching = iota
+	// MatchCaseInsensitive matches names regardless of their case, e.g. "userId" and "UserID".
+	MatchCaseInsensitive
+	// MatchNormalized matches names regardless of case, underscores and dashes, e.g. "user_id", "user-id" and "userId".
+	MatchNormalized
+)
+
+// Option configures a Mapper created with New.
+type Option func(*Mapper)
+
+// WithValidator registers a validator on the new Mapper.
+func WithValidator(name string, f ValidatorFunc) Option {
+	return func(m *Mapper) {
+		m.RegisterValidator(name, f)
+	}
+}
+
+// WithCrossFieldValidator registers a cross-field validator on the new Mapper.
+func WithCrossFieldValidator(name string, f CrossFieldValidatorFunc) Option {
+	return func(m *Mapper) {
+		m.RegisterCrossFieldValidator(name, f)
+	}
+}
+
+// WithTransformer registers a transformer on the new Mapper.
+func WithTransformer(name string, f TransformerFunc) Option {
+	return func(m *Mapper) {
+		m.RegisterTransformer(name, f)
+	}
+}
+
+// WithFallibleTransformer registers a transformer that can fail on the new Mapper.
+func WithFallibleTransformer(name string, f FallibleTransformerFunc) Option {
+	return func(m *Mapper) {
+		m.RegisterFallibleTransformer(name, f)
+	}
+}
+
+// WithParamTransformer registers a transformer that takes an argument on the new Mapper.
+func WithParamTransformer(name string, f ParamTransformerFunc) Option {
+	return func(m *Mapper) {
+		m.RegisterParamTransformer(name, f)
+	}
+}
+
+// WithValidatorContext registers a context-aware validator on the new Mapper.
+func WithValidatorContext(name string, f ValidatorContextFunc) Option {
+	return func(m *Mapper) {
+		m.RegisterValidatorContext(name, f)
+	}
+}
+
+// WithTransformerContext registers a context-aware transformer on the new Mapper.
+func WithTransformerContext(name string, f TransformerContextFunc) Option {
+	return func(m *Mapper) {
+		m.RegisterTransformerContext(name, f)
+	}
+}
+
+// WithTagKey sets the struct tag that holds field names, e.g. "db" or "xmap" instead of "json".
+func WithTagKey(key string) Option {
+	return func(m *Mapper) {
+		m.tagKey = key
+	}
+}
+
+// WithFieldNameFallback makes exported fields without a name tag match by their Go field name.
+func WithFieldNameFallback() Option {
+	return func(m *Mapper) {
+		m.fieldNameFallback = true
+	}
+}
+
+// WithNameMatching sets how source and destination field names are compared.
+func WithNameMatching(matching NameMatching) Option {
+	return func(m *Mapper) {
+		m.nameMatching = matching
+	}
+}
+
+// WithNumericStrings makes the built-in numeric validators gt, lt, gte, lte, range, multipleOf and len
+// also accept strings that hold a number, e.g. "42".
+func WithNumericStrings() Option {
+	return func(m *Mapper) {
+		for name, f := range numericValidators {
+			m.RegisterValidator(name, validators.AllowNumericStrings(f))
+		}
+	}
+}
+
+// numericValidators are the built-in validators that compare numbers.
+var numericValidators = map[string]ValidatorFunc{
+	"gt":         validators.GreaterThanValidator,
+	"lt":         validators.LessThanValidator,
+	"gte":        validators.GreaterThanOrEqualValidator,
+	"lte":        validators.LessThanOrEqualValidator,
+	"range":      validators.RangeValidator,
+	"multipleOf": validators.MultipleOfValidator,  // multipleOf:0.25
+	"len":        validators.DigitLengthValidator, // Number of digits of an integer, e.g. len:4 or len:4-6
+}
+
+// defaultMapper is the Mapper used by the package-level functions.
+var defaultMapper = New()
+
+// New creates a Mapper with the default validators and transformers registered, then applies the options.
+func New(opts ...Option) *Mapper {
+	m := &Mapper{
+		transformers: map[string]registeredTransformer{},
+		validators:   map[string]registeredValidator{},
+		converters:   map[converterKey]convertFunc{},
+		plans:        map[planKey]*structPlan{},
+		tagKey:       "json",
+	}
+	registerDefaults(m)
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Default returns the Mapper used by the package-level functions.
+// Use Default().Clone() to derive a Mapper that starts with everything registered globally.
+func Default() *Mapper {
+	return defaultMapper
+}
+
+// registerDefaults registers the built-in validators and transformers.
+func registerDefaults(m *Mapper) {
+	// Default validators
+	m.RegisterValidator("required", validators.RequiredValidator) // Should not be empty
+	m.RegisterValidator("email", validators.EmailValidator)
+	m.RegisterValidator("phone", validators.PhoneValidator)                   // International phone number format
+	m.RegisterValidator("strongPassword", validators.StrongPasswordValidator) // Minimum 8 characters, at least one uppercase, one lowercase, one number, and one special character
+	m.RegisterValidator("date", validators.DateValidator)                     // Date in YYYY-MM-DD format, or in the layout given as argument
+	m.RegisterValidator("time", validators.TimeValidator)                     // Time in HH:MM:SS format, or in the layout given as argument
+	m.RegisterValidator("datetime", validators.DatetimeValidator)             // Date and time in YYYY-MM-DD HH:MM:SS format with timezone, or e.g. datetime:rfc3339
+	m.RegisterValidator("url", validators.UrlValidator)                       // Optional schemes, e.g. url:https
+	m.RegisterValidator("ip", validators.IpValidator)
+	m.RegisterValidator("minLength", validators.MinLengthValidator)
+	m.RegisterValidator("maxLength", validators.MaxLengthValidator)
+	for name, f := range numericValidators {
+		m.RegisterValidator(name, f)
+	}
+	// enum:'a-b'|'c', contains and notContains take quoted values literally, unquoted ones are split at '-' or ','
+	m.registerValidator("enum", registeredValidator{
+		fn:     plainValidator(validators.EnumValidator),
+		values: valuesValidator(validators.EnumValuesValidator),
+	})
+	m.RegisterValidator("boolean", validators.BooleanValidator)
+	m.registerValidator("contains", registeredValidator{
+		fn:     plainValidator(validators.ContainsValidator),
+		values: valuesValidator(validators.ContainsValuesValidator),
+	})
+	m.registerValidator("notContains", registeredValidator{
+		fn:     plainValidator(validators.NotContainsValidator),
+		values: valuesValidator(validators.NotContainsValuesValidator),
+	})
+	m.RegisterValidator("startsWidth", validators.StartsWidthValidator)
+	m.RegisterValidator("endsWith", validators.EndsWithValidator)
+	m.RegisterValidator("minItems", validators.MinItemsValidator)
+	m.RegisterValidator("maxItems", validators.MaxItemsValidator)
+	m.RegisterValidator("unique", validators.UniqueValidator)
+	m.RegisterValidator("uuid", validators.UuidValidator) // Optional version, e.g. uuid:4
+	m.RegisterValidator("ulid", validators.UlidValidator)
+	m.RegisterValidator("hex", validators.HexValidator)
+	m.RegisterValidator("base64", validators.Base64Validator)
+	m.RegisterValidator("base64url", validators.Base64UrlValidator)
+	m.RegisterValidator("jwt", validators.JwtValidator) // Structure only, the signature is not verified
+	m.RegisterValidator("semver", validators.SemverValidator)
+	m.RegisterValidator("json", validators.JsonValidator)
+	m.RegisterValidator("mongoId", validators.MongoIdValidator)
+	m.RegisterValidator("ipv4", validators.Ipv4Validator)
+	m.RegisterValidator("ipv6", validators.Ipv6Validator)
+	m.RegisterValidator("cidr", validators.CidrValidator)
+	m.RegisterValidator("hostname", validators.HostnameValidator) // RFC 1123
+	m.RegisterValidator("fqdn", validators.FqdnValidator)
+	m.RegisterValidator("port", validators.PortValidator)
+	m.RegisterValidator("mac", validators.MacValidator)
+	m.RegisterValidator("hostPort", validators.HostPortValidator)
+	m.RegisterValidator("publicUrl", validators.PublicUrlValidator)   // Rejects loopback and private hosts, optional schemes like url
+	m.RegisterValidator("creditCard", validators.CreditCardValidator) // Luhn checksum, optional brands, e.g. creditCard:visa
+	m.RegisterValidator("iban", validators.IbanValidator)
+	m.RegisterValidator("bic", validators.BicValidator)
+	m.RegisterValidator("currency", validators.CurrencyValidator) // ISO 4217
+	m.RegisterValidator("decimal", validators.DecimalValidator)   // decimal:'10,2' allows 10 digits of which 2 are fractional
+	m.RegisterValidator("country", validators.CountryValidator)   // ISO 3166-1 alpha-2, or alpha-3 with country:alpha3
+	m.RegisterValidator("language", validators.LanguageValidator) // BCP 47
+	m.RegisterValidator("timezone", validators.TimezoneValidator) // IANA names, the timezone database is embedded
+	m.RegisterValidator("latitude", validators.LatitudeValidator)
+	m.RegisterValidator("longitude", validators.LongitudeValidator)
+	// postalCode:DE, see validators.PostalCodeCountries, the country is checked when the tag is parsed
+	m.registerValidator("postalCode", registeredValidator{
+		fn:       plainValidator(validators.PostalCodeValidator),
+		checkArg: validators.CheckPostalCodeCountry,
+	})
+	m.RegisterValidator("minRunes", validators.MinRunesValidator) // Like minLength but counts characters instead of bytes
+	m.RegisterValidator("maxRunes", validators.MaxRunesValidator)
+	m.RegisterValidator("exactLength", validators.ExactLengthValidator)
+	m.RegisterValidator("alpha", validators.AlphaValidator) // ASCII letters
+	m.RegisterValidator("alphanumeric", validators.AlphanumericValidator)
+	m.RegisterValidator("ascii", validators.AsciiValidator)
+	m.RegisterValidator("printable", validators.PrintableValidator)
+	m.RegisterValidator("numeric", validators.NumericValidator) // Digits only, e.g. for codes with leading zeros
+	m.RegisterValidator("lowercase", validators.LowercaseValidator)
+	m.RegisterValidator("uppercase", validators.UppercaseValidator)
+	m.RegisterValidator("noWhitespace", validators.NoWhitespaceValidator)
+	m.RegisterValidator("unicodeLetters", validators.UnicodeLettersValidator) // Letters of any script
+	// pattern:'^[a-z]+$', the expression is compiled when the tag is parsed
+	m.registerValidator("pattern", registeredValidator{
+		fn: plainValidator(validators.PatternValidator),
+		checkArg: func(pattern string) error {
+			_, err := validators.CompilePattern(pattern)
+			return err
+		},
+	})
+	// password:'min=12,classes=uld', the options are checked when the tag is parsed
+	m.registerValidator("password", registeredValidator{
+		fn: plainValidator(validators.PasswordValidator),
+		checkArg: func(options string) error {
+			_, err := validators.ParsePasswordPolicy(options, validators.DefaultPasswordPolicy())
+			return err
+		},
+	})
+	// before:now-18y, after:now and between:'now-100y'|'now-18y', the bounds are checked when the tag is parsed
+	m.registerValidator("before", registeredValidator{fn: plainValidator(validators.BeforeValidator), checkArg: checkTimeBound})
+	m.registerValidator("after", registeredValidator{fn: plainValidator(validators.AfterValidator), checkArg: checkTimeBound})
+	m.registerValidator("between", registeredValidator{
+		fn: plainValidator(validators.BetweenValidator),
+		checkArg: func(bounds string) error {
+			_, _, err := validators.ParseTimeRange(bounds, time.Now())
+			return err
+		},
+	})
+
+	// Default cross-field validators
+	m.RegisterCrossFieldValidator("eqField", eqFieldValidator)
+	m.RegisterCrossFieldValidator("neField", neFieldValidator)
+	m.RegisterCrossFieldValidator("gtField", gtFieldValidator)
+	m.RegisterCrossFieldValidator("ltField", ltFieldValidator)
+	m.RegisterCrossFieldValidator("requiredWith", requiredWithValidator)
+	m.RegisterCrossFieldValidator("requiredIf", requiredIfValidator)
+	m.RegisterCrossFieldValidator("requiredWithout", requiredWithoutValidator)
+	m.RegisterCrossFieldValidator("requiredUnless", requiredUnlessValidator)
+
+	// Default transformers
+	m.RegisterTransformer("uppercase", transformers.ToUpperCase)
+	m.RegisterTransformer("lowercase", transformers.ToLowerCase)
+	m.RegisterTransformer("trim", transformers.Trim)
+	m.RegisterTransformer("trimLeft", transformers.TrimLeft)
+	m.RegisterTransformer("trimRight", transformers.TrimRight)
+	m.RegisterTransformer("base64Encode", transformers.Base64Encode)
+	m.RegisterFallibleTransformer("base64Decode", transformers.StrictBase64Decode)
+	m.RegisterTransformer("urlEncode", transformers.UrlEncode)
+	m.RegisterFallibleTransformer("urlDecode", transformers.StrictUrlDecode)
+	m.RegisterParamTransformer("truncate", transformers.Truncate) // truncate:50 keeps the first 50 characters
+	// padLeft:10:0 pads to 10 characters with zeros, spaces by default, padRight:10:. pads with dots
+	m.registerTransformer("padLeft", registeredTransformer{
+		fn:     paramTransformer(transformers.PadLeft),
+		values: valuesTransformer(transformers.PadLeftValues),
+	})
+	m.registerTransformer("padRight", registeredTransformer{
+		fn:     paramTransformer(transformers.PadRight),
+		values: valuesTransformer(transformers.PadRightValues),
+	})
+	// replace:old:new or replace:':'|'-' replaces every occurrence of old with new
+	m.registerTransformer("replace", registeredTransformer{
+		fn:     paramTransformer(transformers.Replace),
+		values: valuesTransformer(transformers.ReplaceValues),
+	})
+	// default:value sets empty values to value, including nil pointers and missing map keys
+	m.registerTransformer("default", registeredTransformer{fn: paramTransformer(transformers.Default), fillsMissing: true})
+	m.RegisterParamTransformer("prefix", transformers.Prefix)
+	m.RegisterParamTransformer("suffix", transformers.Suffix)
+	// regexReplace:pattern:replacement or regexReplace:'pattern'|'replacement', the expression is compiled when the tag is parsed
+	m.registerTransformer("regexReplace", registeredTransformer{
+		fn: paramTransformer(transformers.RegexReplace),
+		checkArg: func(arg string) error {
+			_, _, err := transformers.ParseRegexReplace(arg)
+			return err
+		},
+		values: func(values []string) (transformFunc, error) {
+			if _, _, err := transformers.ParseRegexReplaceValues(values); err != nil {
+				return nil, err
+			}
+			return valuesTransformer(transformers.RegexReplaceValues)(values)
+		},
+	})
+}
+
+// Clone returns a new Mapper with a copy of the registries of m, including its converters.
+// Registering on the clone does not affect m and vice versa.
+func (m *Mapper) Clone() *Mapper {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	clone := &Mapper{
+		transformers:      make(map[string]registeredTransformer, len(m.transformers)),
+		validators:        make(map[string]registeredValidator, len(m.validators)),
+		converters:        make(map[converterKey]convertFunc, len(m.converters)),
+		plans:             map[planKey]*structPlan{},
+		tagKey:            m.tagKey,
+		fieldNameFallback: m.fieldNameFallback,
+		nameMatching:      m.nameMatching,
+		floatConversion:   m.floatConversion,
+	}
+	for name, f := range m.transformers {
+		clone.transformers[name] = f
+	}
+	for name, f := range m.validators {
+		clone.validators[name] = f
+	}
+	for key, f := range m.converters {
+		clone.converters[key] = f
+	}
+	return clone
+}
+
+// RegisterTransformer adds a transformer function to the registry of m with a given name.
+func (m *Mapper) RegisterTransformer(name string, f TransformerFunc) {
+	m.registerTransformer(name, registeredTransformer{fn: func(_ context.Context, value interface{}, _ string) (interface{}, error) {
+		return f(value), nil
+	}})
+}
+
+// RegisterFallibleTransformer adds a transformer function that can fail to the registry of m.
+// Its errors are returned as a TransformationError naming the field and the transformer.
+func (m *Mapper) RegisterFallibleTransformer(name string, f FallibleTransformerFunc) {
+	m.registerTransformer(name, registeredTransformer{fn: func(_ context.Context, value interface{}, _ string) (interface{}, error) {
+		return f(value)
+	}})
+}
+
+// RegisterParamTransformer adds a transformer function that takes an argument to the registry of m.
+// Its errors are returned as a TransformationError naming the field and the transformer.
+func (m *Mapper) RegisterParamTransformer(name string, f ParamTransformerFunc) {
+	m.registerTransformer(name, registeredTransformer{fn: paramTransformer(f)})
+}
+
+// RegisterValidator adds a validator function to the registry of m.
+func (m *Mapper) RegisterValidator(name string, f ValidatorFunc) {
+	m.registerValidator(name, registeredValidator{fn: plainValidator(f)})
+}
+
+// RegisterCrossFieldValidator adds a validator function that receives the enclosing struct to the registry of m.
+func (m *Mapper) RegisterCrossFieldValidator(name string, f CrossFieldValidatorFunc) {
+	m.registerValidator(name, registeredValidator{fn: func(_ context.Context, value interface{}, arg string, parent Parent) error {
+		return f(value, arg, parent)
+	}})
+}
+
+// plainValidator wraps a ValidatorFunc into the form validators are stored in.
+func plainValidator(f ValidatorFunc) validateFunc {
+	return func(_ context.Context, value interface{}, arg string, _ Parent) error {
+		return f(value, arg)
+	}
+}
+
+// valuesValidator wraps a validator that takes the values of a quoted argument into the builder of registeredValidator.
+func valuesValidator(f func(input interface{}, values []string) error) func([]string) (validateFunc, error) {
+	return func(values []string) (validateFunc, error) {
+		return func(_ context.Context, value interface{}, _ string, _ Parent) error {
+			return f(value, values)
+		}, nil
+	}
+}
+
+// checkTimeBound reports an error for a bound of the before and after validators that cannot be parsed.
+func checkTimeBound(bound string) error {
+	_, err := validators.ParseTimeBound(bound, time.Now())
+	return err
+}
+
+// registerValidator stores a validator in the registry of m and drops the cached plans.
+func (m *Mapper) registerValidator(name string, validator registeredValidator) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.validators[name] = validator
+	m.invalidatePlans()
+}
+
+// paramTransformer wraps a ParamTransformerFunc into the form transformers are stored in.
+func paramTransformer(f ParamTransformerFunc) transformFunc {
+	return func(_ context.Context, value interface{}, arg string) (interface{}, error) {
+		return f(value, arg)
+	}
+}
+
+// valuesTransformer wraps a transformer that takes the values of a quoted argument into the builder of registeredTransformer.
+func valuesTransformer(f func(input interface{}, values []string) (interface{}, error)) func([]string) (transformFunc, error) {
+	return func(values []string) (transformFunc, error) {
+		return func(_ context.Context, value interface{}, _ string) (interface{}, error) {
+			return f(value, values)
+		}, nil
+	}
+}
+
+// registerTransformer stores a transformer in the registry of m and drops the cached plans.
+func (m *Mapper) registerTransformer(name string, transformer registeredTransformer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.transformers[name] = transformer
+	m.invalidatePlans()
+}
+
+// invalidatePlans drops every cached plan, the caller must hold the write lock.
+func (m *Mapper) invalidatePlans() {
+	m.generation++
+	m.plans = map[planKey]*structPlan{}
+}
+
+// lookupTransformer returns the transformer registered under the given name.
+func (m *Mapper) lookupTransformer(name string) (registeredTransformer, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	transformer, exists := m.transformers[name]
+	return transformer, exists
+}
+
+// lookupValidator returns the validator registered under the given name.
+func (m *Mapper) lookupValidator(name string) (registeredValidator, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	validator, exists := m.validators[name]
+	return validator, exists
+}
@@ -0,0 +1,234 @@
+package xmapper
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"time"
+)
+
+// MapToStruct validates, transforms and maps a map into the destination struct using the default Mapper.
+func MapToStruct(src map[string]interface{}, dest interface{}) error {
+	return defaultMapper.MapToStruct(src, dest)
+}
+
+// StructToMap validates, transforms and converts a struct into a map using the default Mapper.
+func StructToMap(src interface{}) (map[string]interface{}, error) {
+	return defaultMapper.StructToMap(src)
+}
+
+// MapToStruct validates, transforms and maps a map into the destination struct.
+// Keys are matched with the names of the destination fields using the name matching strategy of m,
+// and the validators and transformers declared on the destination struct are applied to the values.
+// Nested maps are mapped into nested structs.
+func (m *Mapper) MapToStruct(src map[string]interface{}, dest interface{}) error {
+	destValue := reflect.ValueOf(dest)
+	if !isValidStructPointer(destValue) {
+		return errors.New("destination must be a pointer to a struct")
+	}
+
+	state := m.newState(context.Background())
+	if err := mapToStructRecursive(state, "", reflect.ValueOf(src), destValue.Elem()); err != nil {
+		return err
+	}
+	return state.result()
+}
+
+// StructToMap validates, transforms and converts a struct or a pointer to a struct into a map keyed by field names.
+// The map tag is ignored, so the keys are the ones MapToStruct looks up and renamed fields cannot collide.
+// Nested structs become nested maps and slices of structs become slices of maps.
+func (m *Mapper) StructToMap(src interface{}) (map[string]interface{}, error) {
+	srcValue := reflect.ValueOf(src)
+	if srcValue.Kind() == reflect.Ptr && !srcValue.IsNil() {
+		srcValue = srcValue.Elem()
+	}
+	if srcValue.Kind() != reflect.Struct {
+		return nil, errors.New("source must be a struct or a pointer to a struct")
+	}
+	if !srcValue.CanAddr() {
+		// Copy the struct so hooks with pointer receivers can be called
+		srcCopy := reflect.New(srcValue.Type()).Elem()
+		srcCopy.Set(srcValue)
+		srcValue = srcCopy
+	}
+
+	state := m.newState(context.Background())
+	result, err := structToMapRecursive(state, "", srcValue)
+	if err != nil {
+		return nil, err
+	}
+	if err := state.result(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// mapToStructRecursive maps a map with string keys into the destination struct.
+// Missing keys are validated as the zero value of the field and leave the field untouched, unless a transformer such as
+// default fills it in.
+func mapToStructRecursive(state *mappingState, path string, srcMap, destStruct reflect.Value) error {
+	plan, err := state.mapper.plan(destStruct.Type(), destStruct.Type())
+	if err != nil {
+		return err
+	}
+
+	for _, fieldPlan := range plan.fields {
+		if err := state.ctx.Err(); err != nil {
+			return err
+		}
+		if fieldPlan.name == "" {
+			continue
+		}
+
+		destField := destStruct.Field(fieldPlan.srcIndex)
+		fieldPath := joinPath(path, fieldPlan.name)
+
+		srcValue := state.mapper.lookupMapKey(srcMap, fieldPlan.name)
+		value := reflect.Zero(destField.Type()).Interface()
+		if srcValue.IsValid() {
+			value = srcValue.Interface()
+		}
+
+		if !state.runValidators(fieldPath, value, fieldPlan.validators, state.parent(srcMap)) {
+			continue
+		}
+
+		if !destField.CanSet() {
+			continue
+		}
+		if !srcValue.IsValid() {
+			err = setMissingValue(state, fieldPath, destField.Type(), destField, fieldPlan.transformers)
+		} else {
+			err = setFieldValue(state, fieldPath, srcValue, destField, fieldPlan.transformers)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	// The map has no methods, so the struct-level hooks run on the mapped struct
+	state.runStructHooks(path, destStruct)
+	return nil
+}
+
+// lookupMapKey returns the map value for the field name, comparing keys with the name matching strategy of m.
+func (m *Mapper) lookupMapKey(srcMap reflect.Value, name string) reflect.Value {
+	if value := srcMap.MapIndex(reflect.ValueOf(name).Convert(srcMap.Type().Key())); value.IsValid() || m.nameMatching == MatchExact {
+		return value
+	}
+
+	key := m.matchKey(name)
+	iter := srcMap.MapRange()
+	for iter.Next() {
+		if m.matchKey(iter.Key().String()) == key {
+			return iter.Value()
+		}
+	}
+	return reflect.Value{}
+}
+
+// structToMapRecursive converts a struct into a map keyed by the names of its fields.
+func structToMapRecursive(state *mappingState, path string, srcStruct reflect.Value) (map[string]interface{}, error) {
+	plan, err := state.mapper.plan(srcStruct.Type(), srcStruct.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]interface{}, len(plan.fields))
+	for _, fieldPlan := range plan.fields {
+		if err := state.ctx.Err(); err != nil {
+			return nil, err
+		}
+		srcField := srcStruct.Field(fieldPlan.srcIndex)
+		if fieldPlan.name == "" || !srcField.CanInterface() {
+			continue
+		}
+
+		fieldPath := joinPath(path, fieldPlan.name)
+		if !state.runValidators(fieldPath, srcField.Interface(), fieldPlan.validators, state.parent(srcStruct)) {
+			continue
+		}
+
+		value, err := toMapValue(state, fieldPath, srcField, fieldPlan.transformers)
+		if err != nil {
+			return nil, err
+		}
+		result[fieldPlan.name] = value
+	}
+
+	state.runStructHooks(path, srcStruct)
+	return result, nil
+}
+
+// toMapValue converts a field value for StructToMap, turning structs into maps and applying transformers to other values.
+func toMapValue(state *mappingState, path string, value reflect.Value, transformers []fieldTransformer) (interface{}, error) {
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil, nil
+		}
+		value = value.Elem()
+	}
+
+	switch {
+	case value.Kind() == reflect.Struct && value.Type() != reflect.TypeOf(time.Time{}):
+		return structToMapRecursive(state, path, value)
+	case value.Kind() == reflect.Slice && isStructOrStructPointer(value.Type().Elem()):
+		if value.IsNil() {
+			return nil, nil
+		}
+		items := make([]interface{}, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			if err := state.ctx.Err(); err != nil {
+				return nil, err
+			}
+			item, err := toMapValue(state, indexPath(path, i), value.Index(i), nil)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = item
+		}
+		return items, nil
+	case value.Kind() == reflect.Slice && len(transformers) > 0:
+		// Transformers apply to each element, as they do in MapStructs
+		if value.IsNil() {
+			return value.Interface(), nil
+		}
+		return transformSliceElements(state, path, value, transformers)
+	}
+
+	return state.runTransformers(path, value.Interface(), transformers)
+}
+
+// transformSliceElements applies the transformers to each element of the slice. The result keeps the type of the slice
+// unless a transformer returns a value of another type, then it is a []interface{}.
+func transformSliceElements(state *mappingState, path string, value reflect.Value, transformers []fieldTransformer) (interface{}, error) {
+	items := make([]interface{}, value.Len())
+	typed := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+	for i := 0; i < value.Len(); i++ {
+		if err := state.ctx.Err(); err != nil {
+			return nil, err
+		}
+		item, err := toMapValue(state, indexPath(path, i), value.Index(i), transformers)
+		if err != nil {
+			return nil, err
+		}
+		items[i] = item
+		if itemValue := reflect.ValueOf(item); typed.IsValid() && itemValue.IsValid() && itemValue.Type().AssignableTo(typed.Type().Elem()) {
+			typed.Index(i).Set(itemValue)
+		} else {
+			typed = reflect.Value{}
+		}
+	}
+	if typed.IsValid() {
+		return typed.Interface(), nil
+	}
+	return items, nil
+}
+
+// isStructOrStructPointer reports whether the type is a struct other than time.Time, or a pointer to one.
+func isStructOrStructPointer(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct && t != reflect.TypeOf(time.Time{})
+}
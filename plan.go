@@ -0,0 +1,96 @@
+package xmapper
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// planKey identifies a compiled plan by its source and destination struct types.
+type planKey struct {
+	src  reflect.Type
+	dest reflect.Type
+}
+
+// structPlan is the compiled form of the tags of a source struct matched against a destination struct,
+// so tags are parsed and fields are matched only once per type pair.
+type structPlan struct {
+	fields []fieldPlan
+}
+
+// fieldPlan describes how a single source field is validated, transformed and mapped.
+type fieldPlan struct {
+	name         string // name used in error paths
+	mapName      string // name used to match the destination, overridden by the map tag
+	srcIndex     int
+	destIndex    int // -1 when the destination has no matching field
+	validators   validatorChain
+	transformers []fieldTransformer
+}
+
+// plan returns the cached plan for the type pair, compiling it on first use.
+// Plans compiled while the registries change are not cached, since they may refer to replaced functions.
+func (m *Mapper) plan(srcType, destType reflect.Type) (*structPlan, error) {
+	key := planKey{src: srcType, dest: destType}
+
+	m.mu.RLock()
+	plan, ok := m.plans[key]
+	generation := m.generation
+	m.mu.RUnlock()
+	if ok {
+		return plan, nil
+	}
+
+	plan, err := m.compilePlan(srcType, destType)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	if m.generation == generation {
+		m.plans[key] = plan
+	}
+	m.mu.Unlock()
+	return plan, nil
+}
+
+// compilePlan parses the tags of every source field and matches it with a destination field by name.
+func (m *Mapper) compilePlan(srcType, destType reflect.Type) (*structPlan, error) {
+	destMap := m.buildDestinationFieldMap(destType)
+
+	plan := &structPlan{fields: make([]fieldPlan, 0, srcType.NumField())}
+	for i := 0; i < srcType.NumField(); i++ {
+		field := srcType.Field(i)
+		fieldPlan := fieldPlan{
+			name:      m.fieldName(field),
+			srcIndex:  i,
+			destIndex: -1,
+		}
+		fieldPlan.mapName = fieldPlan.name
+		if rename := field.Tag.Get("map"); rename != "" && fieldPlan.name != "" {
+			fieldPlan.mapName = rename
+		}
+
+		if transformerNames := field.Tag.Get("transformers"); transformerNames != "" {
+			transformers, err := m.parseTransformers(transformerNames)
+			if err != nil {
+				return nil, err
+			}
+			fieldPlan.transformers = transformers
+		}
+
+		if validatorSpec := field.Tag.Get("validators"); validatorSpec != "" {
+			validators, err := m.parseFieldValidators(validatorSpec)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing validators for field '%s': %w", fieldPlan.name, err)
+			}
+			fieldPlan.validators = validators
+		}
+
+		if destIndex, ok := destMap[m.matchKey(fieldPlan.mapName)]; ok && fieldPlan.name != "" {
+			fieldPlan.destIndex = destIndex
+		}
+
+		plan.fields = append(plan.fields, fieldPlan)
+	}
+	return plan, nil
+}
@@ -0,0 +1,413 @@
+package xmapper
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SyntaxError reports a malformed validators or transformers tag, or a malformed ValidateSingleField spec.
+type SyntaxError struct {
+	// Input is the tag or spec that failed to parse.
+	Input string
+	// Offset is the byte offset in Input at which the error was found.
+	Offset int
+	// Message describes the error.
+	Message string
+}
+
+// Error implements the error interface.
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at offset %d in '%s': %s", e.Offset, e.Input, e.Message)
+}
+
+// tagEntry is a single "name" or "name:arg" entry of a validators or transformers tag.
+type tagEntry struct {
+	name string
+	// arg is the decoded argument passed to the function, several quoted values are joined by joinArgs.
+	arg string
+	// text is the argument as written in the tag, e.g. 'a-b'|'c'.
+	text string
+	// values holds the values of a quoted argument, it is nil for an unquoted one.
+	values []string
+}
+
+// exprOp is the kind of a node of a parsed validators tag.
+type exprOp int
+
+const (
+	// exprEntry is a single entry such as "minLength:5".
+	exprEntry exprOp = iota
+	// exprAnd is a group of comma-separated terms in parentheses, all of which must pass.
+	exprAnd
+	// exprOr is a list of alternatives separated by '|', any of which must pass.
+	exprOr
+	// exprNot is a term preceded by '!', which must fail.
+	exprNot
+)
+
+// tagExpr is a node of a parsed validators tag: a single entry, or a combination of other nodes.
+type tagExpr struct {
+	op       exprOp
+	entry    tagEntry
+	operands []tagExpr
+	// text is the node as written in the tag, used to report which branch failed.
+	text string
+}
+
+// isEntry reports whether the node is a single entry with the given name.
+func (e tagExpr) isEntry(name string) bool {
+	return e.op == exprEntry && e.entry.name == name
+}
+
+// tagScanner reads a tag or spec byte by byte and remembers the current offset for syntax errors.
+type tagScanner struct {
+	input  string
+	offset int
+	// expressions enables the operators of validators tags: '|', '!' and parentheses.
+	expressions bool
+	// depth is the number of open parentheses.
+	depth int
+}
+
+// parseTagEntries parses a comma-separated list of entries.
+//
+// An entry is a name optionally followed by ':' and an argument. An unquoted argument runs until the next comma,
+// a comma inside it is written as "\,". A quoted argument is enclosed in single or double quotes, in which commas
+// have no special meaning and "\'", "\"" and "\\" are escapes. Several quoted values can be separated with '|',
+// e.g. enum:'a-b'|'c'. A single quoted value is passed to the function as it is, several are passed as a single argument
+// that validators.SplitArgs splits again. The values are also kept apart for functions that need them exactly.
+func parseTagEntries(tag string) ([]tagEntry, error) {
+	s := &tagScanner{input: tag}
+	var entries []tagEntry
+	for {
+		entry, err := s.entry()
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+
+		s.skipSpaces()
+		if s.done() {
+			return entries, nil
+		}
+		if s.peek() != ',' {
+			return nil, s.errorf("expected ',' but found '%c'", s.peek())
+		}
+		s.offset++
+	}
+}
+
+// parseTagExpression parses a validators tag into its top-level terms, which are separated by commas.
+//
+// Terms are the entries of parseTagEntries, combined with '|' where any alternative must pass, negated with a
+// leading '!' and grouped with parentheses, e.g. "required,email|phone,!(contains:admin|contains:root)".
+// '|' binds tighter than ','. Unquoted arguments also end at '|', and at ')' inside parentheses,
+// "\|" and "\)" keep these characters in the argument. An argument with "\|" is a single literal value like a quoted one,
+// so contains:x\|y looks for "x|y". A '|' followed by a quote continues a list of quoted values.
+func parseTagExpression(tag string) ([]tagExpr, error) {
+	s := &tagScanner{input: tag, expressions: true}
+	terms, err := s.sequence()
+	if err != nil {
+		return nil, err
+	}
+	if !s.done() {
+		return nil, s.errorf("unexpected ')'")
+	}
+	return terms, nil
+}
+
+// sequence reads comma-separated terms until the end of the input or a closing parenthesis.
+func (s *tagScanner) sequence() ([]tagExpr, error) {
+	var terms []tagExpr
+	for {
+		term, err := s.alternatives()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, term)
+
+		s.skipSpaces()
+		if s.done() || s.peek() == ')' {
+			return terms, nil
+		}
+		if s.peek() != ',' {
+			return nil, s.errorf("expected ',' but found '%c'", s.peek())
+		}
+		s.offset++
+	}
+}
+
+// alternatives reads one or more terms separated by '|'.
+func (s *tagScanner) alternatives() (tagExpr, error) {
+	s.skipSpaces()
+	start := s.offset
+	first, err := s.unary()
+	if err != nil {
+		return first, err
+	}
+
+	operands := []tagExpr{first}
+	for {
+		s.skipSpaces()
+		if s.done() || s.peek() != '|' {
+			break
+		}
+		s.offset++
+		operand, err := s.unary()
+		if err != nil {
+			return operand, err
+		}
+		operands = append(operands, operand)
+	}
+	if len(operands) == 1 {
+		return first, nil
+	}
+	return tagExpr{op: exprOr, operands: operands, text: s.textFrom(start)}, nil
+}
+
+// unary reads a negated term, a group in parentheses or a single entry.
+func (s *tagScanner) unary() (tagExpr, error) {
+	s.skipSpaces()
+	start := s.offset
+	switch {
+	case !s.done() && s.peek() == '!':
+		s.offset++
+		operand, err := s.unary()
+		if err != nil {
+			return operand, err
+		}
+		return tagExpr{op: exprNot, operands: []tagExpr{operand}, text: s.textFrom(start)}, nil
+	case !s.done() && s.peek() == '(':
+		s.offset++
+		s.depth++
+		terms, err := s.sequence()
+		if err != nil {
+			return tagExpr{}, err
+		}
+		if s.done() {
+			return tagExpr{}, &SyntaxError{Input: s.input, Offset: start, Message: "unclosed '('"}
+		}
+		s.offset++
+		s.depth--
+		return tagExpr{op: exprAnd, operands: terms, text: s.textFrom(start)}, nil
+	}
+
+	entry, err := s.entry()
+	if err != nil {
+		return tagExpr{}, err
+	}
+	return tagExpr{op: exprEntry, entry: entry, text: s.textFrom(start)}, nil
+}
+
+// textFrom returns the input read since start without surrounding spaces.
+func (s *tagScanner) textFrom(start int) string {
+	return strings.TrimSpace(s.input[start:s.offset])
+}
+
+// entry reads a single "name" or "name:arg" entry.
+func (s *tagScanner) entry() (tagEntry, error) {
+	s.skipSpaces()
+	start := s.offset
+	for !s.done() && s.peek() != ':' && s.peek() != ',' && !(s.expressions && strings.IndexByte("|()", s.peek()) >= 0) {
+		s.offset++
+	}
+	entry := tagEntry{name: strings.TrimSpace(s.input[start:s.offset])}
+	if entry.name == "" {
+		return entry, s.errorf("expected a name")
+	}
+	if strings.ContainsAny(entry.name, `'"`) {
+		return entry, &SyntaxError{Input: s.input, Offset: start, Message: fmt.Sprintf("unexpected quote in name '%s'", entry.name)}
+	}
+
+	if s.done() || s.peek() != ':' {
+		return entry, nil
+	}
+	s.offset++
+
+	// Quotes only have a special meaning at the start of an argument
+	argStart := s.offset
+	s.skipSpaces()
+	if !s.done() && (s.peek() == '\'' || s.peek() == '"') {
+		values, err := s.quotedList()
+		if err != nil {
+			return entry, err
+		}
+		entry.arg = joinArgs(values)
+		entry.text = s.textFrom(argStart)
+		entry.values = values
+		return entry, nil
+	}
+
+	s.offset = argStart
+	arg, escapedBar := s.unquoted()
+	entry.arg = arg
+	entry.text = s.textFrom(argStart)
+	// An escaped '|' would be read as a separator again by functions that split lists, keep it as a single value
+	if escapedBar {
+		entry.arg = strings.TrimSpace(arg)
+		entry.values = []string{entry.arg}
+	}
+	return entry, nil
+}
+
+// unquoted reads an argument until the next comma that is not escaped, or the next '|' or closing parenthesis in expressions.
+// It reports whether the argument contains an escaped '|'.
+func (s *tagScanner) unquoted() (string, bool) {
+	var arg strings.Builder
+	escapedBar := false
+	for !s.done() && !s.endsArgument(s.peek()) {
+		if s.peek() == '\\' && s.offset+1 < len(s.input) && s.endsArgument(s.input[s.offset+1]) {
+			s.offset++
+			escapedBar = escapedBar || s.peek() == '|'
+		}
+		arg.WriteByte(s.peek())
+		s.offset++
+	}
+	return arg.String(), escapedBar
+}
+
+// quotedList reads one or more quoted values separated by '|'.
+func (s *tagScanner) quotedList() ([]string, error) {
+	var values []string
+	for {
+		value, err := s.quoted()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+
+		s.skipSpaces()
+		if s.done() || s.peek() == ',' || (s.expressions && s.depth > 0 && s.peek() == ')') {
+			return values, nil
+		}
+		if s.peek() != '|' {
+			return nil, s.errorf("unexpected '%c' after quoted value", s.peek())
+		}
+		// In expressions, a '|' that is not followed by a quote starts another alternative
+		if s.expressions && !s.quoteFollows(s.offset+1) {
+			return values, nil
+		}
+		s.offset++
+		s.skipSpaces()
+		if s.done() || (s.peek() != '\'' && s.peek() != '"') {
+			return nil, s.errorf("expected a quoted value after '|'")
+		}
+	}
+}
+
+// quoted reads a value enclosed in single or double quotes, starting at the opening quote.
+// Backslashes before the closing quote or another backslash are escapes, other backslashes are kept.
+func (s *tagScanner) quoted() (string, error) {
+	start := s.offset
+	quote := s.peek()
+	s.offset++
+
+	var value strings.Builder
+	for !s.done() {
+		c := s.peek()
+		switch {
+		case c == quote:
+			s.offset++
+			return value.String(), nil
+		case c == '\\' && s.offset+1 < len(s.input) && (s.input[s.offset+1] == quote || s.input[s.offset+1] == '\\'):
+			s.offset++
+			value.WriteByte(s.peek())
+		default:
+			value.WriteByte(c)
+		}
+		s.offset++
+	}
+	return "", &SyntaxError{Input: s.input, Offset: start, Message: "unterminated quoted value"}
+}
+
+// endsArgument reports whether the byte ends an unquoted argument.
+func (s *tagScanner) endsArgument(c byte) bool {
+	return c == ',' || (s.expressions && (c == '|' || (s.depth > 0 && c == ')')))
+}
+
+// quoteFollows reports whether the next byte from the offset that is not a space is a quote.
+func (s *tagScanner) quoteFollows(offset int) bool {
+	for offset < len(s.input) && (s.input[offset] == ' ' || s.input[offset] == '\t') {
+		offset++
+	}
+	return offset < len(s.input) && (s.input[offset] == '\'' || s.input[offset] == '"')
+}
+
+// skipSpaces advances past spaces and tabs.
+func (s *tagScanner) skipSpaces() {
+	for !s.done() && (s.peek() == ' ' || s.peek() == '\t') {
+		s.offset++
+	}
+}
+
+// done reports whether the whole input has been read.
+func (s *tagScanner) done() bool {
+	return s.offset >= len(s.input)
+}
+
+// peek returns the byte at the current offset.
+func (s *tagScanner) peek() byte {
+	return s.input[s.offset]
+}
+
+// errorf returns a SyntaxError at the current offset.
+func (s *tagScanner) errorf(format string, args ...interface{}) *SyntaxError {
+	return &SyntaxError{Input: s.input, Offset: s.offset, Message: fmt.Sprintf(format, args...)}
+}
+
+// joinArgs encodes several values as one argument, escaping '|' and '\' inside the values.
+// A single value is returned unchanged so functions that take one argument receive it as written.
+func joinArgs(values []string) string {
+	if len(values) == 1 {
+		return values[0]
+	}
+	escaped := make([]string, len(values))
+	for i, value := range values {
+		escaped[i] = strings.NewReplacer(`\`, `\\`, `|`, `\|`).Replace(value)
+	}
+	return strings.Join(escaped, "|")
+}
+
+// parseSingleFieldSpec parses a ValidateSingleField spec such as "validators:'required,email' transformers:'trim'".
+// The values are quoted with single or double quotes and use the same escapes as quoted tag arguments.
+func parseSingleFieldSpec(spec string) (validatorSpec, transformerSpec string, err error) {
+	s := &tagScanner{input: spec}
+	seen := map[string]bool{}
+	for {
+		s.skipSpaces()
+		if s.done() {
+			return validatorSpec, transformerSpec, nil
+		}
+
+		start := s.offset
+		for !s.done() && s.peek() != ':' && s.peek() != ' ' {
+			s.offset++
+		}
+		key := s.input[start:s.offset]
+		if key != "validators" && key != "transformers" {
+			return "", "", &SyntaxError{Input: spec, Offset: start, Message: fmt.Sprintf("expected 'validators' or 'transformers' but found '%s'", key)}
+		}
+		if seen[key] {
+			return "", "", &SyntaxError{Input: spec, Offset: start, Message: fmt.Sprintf("'%s' is given more than once", key)}
+		}
+		seen[key] = true
+
+		if s.done() || s.peek() != ':' {
+			return "", "", s.errorf("expected ':' after '%s'", key)
+		}
+		s.offset++
+		if s.done() || (s.peek() != '\'' && s.peek() != '"') {
+			return "", "", s.errorf("expected a quoted value after '%s:'", key)
+		}
+		value, err := s.quoted()
+		if err != nil {
+			return "", "", err
+		}
+
+		if key == "validators" {
+			validatorSpec = value
+		} else {
+			transformerSpec = value
+		}
+	}
+}
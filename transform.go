@@ -0,0 +1,61 @@
+package xmapper
+
+import "reflect"
+
+// fieldTransformer is a transformer parsed from a tag together with the name and argument it was declared with.
+type fieldTransformer struct {
+	name         string
+	arg          string
+	fn           transformFunc
+	fillsMissing bool
+}
+
+// runTransformers applies the transformers to the value in order.
+// It stops at the first failing transformer and returns its error as a TransformationError.
+func (s *mappingState) runTransformers(path string, value interface{}, transformers []fieldTransformer) (interface{}, error) {
+	for _, transformer := range transformers {
+		transformed, err := transformer.fn(s.ctx, value, transformer.arg)
+		if err != nil {
+			return nil, &TransformationError{
+				Path:        path,
+				Transformer: transformer.name,
+				Value:       value,
+				Err:         err,
+			}
+		}
+		value = transformed
+	}
+	return value, nil
+}
+
+// setMissingValue fills the destination field when the source has no value, e.g. a nil pointer or a missing map key.
+// The transformers run on the zero value of valueType only if one of them fills missing values, like default, and the
+// field is left untouched when the result is still a zero value.
+func setMissingValue(state *mappingState, path string, valueType reflect.Type, destField reflect.Value, transformers []fieldTransformer) error {
+	fills := false
+	for _, transformer := range transformers {
+		fills = fills || transformer.fillsMissing
+	}
+	for valueType.Kind() == reflect.Ptr {
+		valueType = valueType.Elem()
+	}
+	if !fills || valueType.Kind() == reflect.Struct {
+		return nil
+	}
+
+	value, err := state.runTransformers(path, reflect.Zero(valueType).Interface(), transformers)
+	if err != nil {
+		return err
+	}
+	result := reflect.ValueOf(value)
+	if !result.IsValid() || result.IsZero() {
+		return nil
+	}
+	for destField.Kind() == reflect.Ptr {
+		if destField.IsNil() {
+			destField.Set(reflect.New(destField.Type().Elem()))
+		}
+		destField = destField.Elem()
+	}
+	return assignValue(state, path, result, destField)
+}
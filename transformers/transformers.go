@@ -2,8 +2,15 @@ package transformers
 
 import (
 	"encoding/base64"
+	"fmt"
 	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
 	"strings"
+	"unicode/utf8"
+
+	"github.com/dev3mike/go-xmapper/validators"
 )
 
 // ToUpperCase: Convert string to uppercase
@@ -65,6 +72,19 @@ func Base64Decode(input interface{}) interface{} {
 	return input
 }
 
+// StrictBase64Decode: Decode base64 string, returns an error if the input is not valid base64
+func StrictBase64Decode(input interface{}) (interface{}, error) {
+	str, ok := input.(string)
+	if !ok {
+		return input, nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(str)
+	if err != nil {
+		return input, fmt.Errorf("input is not valid base64: %w", err)
+	}
+	return string(decoded), nil
+}
+
 // UrlEncode: Encode string to URL
 func UrlEncode(input interface{}) interface{} {
 	if str, ok := input.(string); ok {
@@ -83,3 +103,272 @@ func UrlDecode(input interface{}) interface{} {
 	}
 	return input
 }
+
+// StrictUrlDecode: Decode URL string, returns an error if the input is not a valid URL encoded string
+func StrictUrlDecode(input interface{}) (interface{}, error) {
+	str, ok := input.(string)
+	if !ok {
+		return input, nil
+	}
+	decoded, err := url.QueryUnescape(str)
+	if err != nil {
+		return input, fmt.Errorf("input is not valid URL encoding: %w", err)
+	}
+	return decoded, nil
+}
+
+// Truncate: Cut string to at most N characters, the argument is N
+func Truncate(input interface{}, arg string) (interface{}, error) {
+	str, ok := input.(string)
+	if !ok {
+		return input, nil
+	}
+	length, err := parseLength(arg)
+	if err != nil {
+		return input, err
+	}
+	if utf8.RuneCountInString(str) <= length {
+		return str, nil
+	}
+	return string([]rune(str)[:length]), nil
+}
+
+// PadLeft: Pad string on the left to N characters, the argument is "N" or "N:char" and the default char is a space
+func PadLeft(input interface{}, arg string) (interface{}, error) {
+	str, ok := input.(string)
+	if !ok {
+		return input, nil
+	}
+	padding, err := parsePadding(str, arg)
+	if err != nil {
+		return input, err
+	}
+	return padding + str, nil
+}
+
+// PadLeftValues: Like PadLeft, the values are the length and optionally the char, e.g. from '10'|'0'
+func PadLeftValues(input interface{}, values []string) (interface{}, error) {
+	str, ok := input.(string)
+	if !ok {
+		return input, nil
+	}
+	length, char, found := pairValues(values)
+	padding, err := paddingFor(str, length, char, found)
+	if err != nil {
+		return input, err
+	}
+	return padding + str, nil
+}
+
+// PadRight: Pad string on the right to N characters, the argument is "N" or "N:char" and the default char is a space
+func PadRight(input interface{}, arg string) (interface{}, error) {
+	str, ok := input.(string)
+	if !ok {
+		return input, nil
+	}
+	padding, err := parsePadding(str, arg)
+	if err != nil {
+		return input, err
+	}
+	return str + padding, nil
+}
+
+// PadRightValues: Like PadRight, the values are the length and optionally the char, e.g. from '10'|'.'
+func PadRightValues(input interface{}, values []string) (interface{}, error) {
+	str, ok := input.(string)
+	if !ok {
+		return input, nil
+	}
+	length, char, found := pairValues(values)
+	padding, err := paddingFor(str, length, char, found)
+	if err != nil {
+		return input, err
+	}
+	return str + padding, nil
+}
+
+// Replace: Replace every occurrence of a substring, the argument is "old:new", see ReplaceValues when old contains a colon
+func Replace(input interface{}, arg string) (interface{}, error) {
+	str, ok := input.(string)
+	if !ok {
+		return input, nil
+	}
+	old, replacement, found := strings.Cut(arg, ":")
+	if !found || old == "" {
+		return input, fmt.Errorf("replace format is incorrect, must be 'old:new' or 'old'|'new'")
+	}
+	return strings.ReplaceAll(str, old, replacement), nil
+}
+
+// ReplaceValues: Like Replace, the values are old and optionally new, e.g. from ':'|'-'. Both are taken literally
+func ReplaceValues(input interface{}, values []string) (interface{}, error) {
+	str, ok := input.(string)
+	if !ok {
+		return input, nil
+	}
+	old, replacement, _ := pairValues(values)
+	if len(values) > 2 || old == "" {
+		return input, fmt.Errorf("replace format is incorrect, must be 'old'|'new'")
+	}
+	return strings.ReplaceAll(str, old, replacement), nil
+}
+
+// RegexReplace: Replace every match of a regular expression, the argument is "pattern:replacement",
+// see RegexReplaceValues when the pattern contains a colon. The replacement may refer to capture groups, e.g. "$1"
+func RegexReplace(input interface{}, arg string) (interface{}, error) {
+	str, ok := input.(string)
+	if !ok {
+		return input, nil
+	}
+	re, replacement, err := ParseRegexReplace(arg)
+	if err != nil {
+		return input, err
+	}
+	return re.ReplaceAllString(str, replacement), nil
+}
+
+// ParseRegexReplace parses the argument of RegexReplace, every distinct pattern is compiled only once
+func ParseRegexReplace(arg string) (*regexp.Regexp, string, error) {
+	pattern, replacement, found := strings.Cut(arg, ":")
+	if !found || pattern == "" {
+		return nil, "", fmt.Errorf("regexReplace format is incorrect, must be 'pattern:replacement' or 'pattern'|'replacement'")
+	}
+	re, err := validators.CompilePattern(pattern)
+	if err != nil {
+		return nil, "", err
+	}
+	return re, replacement, nil
+}
+
+// RegexReplaceValues: Like RegexReplace, the values are the pattern and optionally the replacement, e.g. from ':+'|'-'
+func RegexReplaceValues(input interface{}, values []string) (interface{}, error) {
+	str, ok := input.(string)
+	if !ok {
+		return input, nil
+	}
+	re, replacement, err := ParseRegexReplaceValues(values)
+	if err != nil {
+		return input, err
+	}
+	return re.ReplaceAllString(str, replacement), nil
+}
+
+// ParseRegexReplaceValues parses the values of RegexReplaceValues, every distinct pattern is compiled only once
+func ParseRegexReplaceValues(values []string) (*regexp.Regexp, string, error) {
+	pattern, replacement, _ := pairValues(values)
+	if len(values) > 2 || pattern == "" {
+		return nil, "", fmt.Errorf("regexReplace format is incorrect, must be 'pattern'|'replacement'")
+	}
+	re, err := validators.CompilePattern(pattern)
+	if err != nil {
+		return nil, "", err
+	}
+	return re, replacement, nil
+}
+
+// Default: Replace an empty string or a zero number or boolean with the argument
+func Default(input interface{}, arg string) (interface{}, error) {
+	value := reflect.ValueOf(input)
+	if !value.IsValid() {
+		return arg, nil
+	}
+	if !value.IsZero() {
+		return input, nil
+	}
+
+	result := reflect.New(value.Type()).Elem()
+	switch value.Kind() {
+	case reflect.String:
+		result.SetString(arg)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		number, err := strconv.ParseInt(arg, 10, value.Type().Bits())
+		if err != nil {
+			return input, fmt.Errorf("default value '%s' is not a valid %s", arg, value.Type())
+		}
+		result.SetInt(number)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		number, err := strconv.ParseUint(arg, 10, value.Type().Bits())
+		if err != nil {
+			return input, fmt.Errorf("default value '%s' is not a valid %s", arg, value.Type())
+		}
+		result.SetUint(number)
+	case reflect.Float32, reflect.Float64:
+		number, err := strconv.ParseFloat(arg, value.Type().Bits())
+		if err != nil {
+			return input, fmt.Errorf("default value '%s' is not a valid %s", arg, value.Type())
+		}
+		result.SetFloat(number)
+	case reflect.Bool:
+		boolean, err := strconv.ParseBool(arg)
+		if err != nil {
+			return input, fmt.Errorf("default value '%s' is not a valid %s", arg, value.Type())
+		}
+		result.SetBool(boolean)
+	default:
+		return input, nil
+	}
+	return result.Interface(), nil
+}
+
+// Prefix: Add the argument to the start of string
+func Prefix(input interface{}, arg string) (interface{}, error) {
+	if str, ok := input.(string); ok {
+		return arg + str, nil
+	}
+	return input, nil
+}
+
+// Suffix: Add the argument to the end of string
+func Suffix(input interface{}, arg string) (interface{}, error) {
+	if str, ok := input.(string); ok {
+		return str + arg, nil
+	}
+	return input, nil
+}
+
+// parseLength parses a non-negative length argument.
+func parseLength(arg string) (int, error) {
+	length, err := strconv.Atoi(strings.TrimSpace(arg))
+	if err != nil || length < 0 {
+		return 0, fmt.Errorf("length must be a non-negative integer, got '%s'", arg)
+	}
+	return length, nil
+}
+
+// parsePadding returns the padding needed to extend str to the length given in the "N:char" argument.
+func parsePadding(str, arg string) (string, error) {
+	lengthArg, char, found := strings.Cut(arg, ":")
+	return paddingFor(str, lengthArg, char, found)
+}
+
+// paddingFor returns the padding needed to extend str to the given length with the char, a space when there is none.
+func paddingFor(str, lengthArg, char string, found bool) (string, error) {
+	length, err := parseLength(lengthArg)
+	if err != nil {
+		return "", err
+	}
+	if !found || char == "" {
+		char = " "
+	}
+	if utf8.RuneCountInString(char) != 1 {
+		return "", fmt.Errorf("padding must be a single character, got '%s'", char)
+	}
+
+	missing := length - utf8.RuneCountInString(str)
+	if missing <= 0 {
+		return "", nil
+	}
+	return strings.Repeat(char, missing), nil
+}
+
+// pairValues returns the first two values and reports whether there is a second value.
+func pairValues(values []string) (string, string, bool) {
+	switch len(values) {
+	case 0:
+		return "", "", false
+	case 1:
+		return values[0], "", false
+	default:
+		return values[0], values[1], true
+	}
+}
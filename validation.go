@@ -0,0 +1,333 @@
+package xmapper
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// validatorNode is a node of the expression tree of a validators tag.
+type validatorNode interface {
+	// validate runs the node against the value and returns the error of the failing validator, or nil.
+	validate(s *mappingState, value interface{}, parent Parent) error
+	// String returns the node as written in the tag.
+	String() string
+}
+
+// fieldValidator is a validator parsed from a tag together with the name and argument it was declared with.
+type fieldValidator struct {
+	name string
+	// arg is the argument as written in the tag.
+	arg string
+	// param is the decoded argument passed to fn.
+	param string
+	fn    validateFunc
+}
+
+func (v fieldValidator) validate(s *mappingState, value interface{}, parent Parent) error {
+	return v.fn(s.ctx, value, v.param, parent)
+}
+
+func (v fieldValidator) String() string {
+	if v.arg == "" {
+		return v.name
+	}
+	return v.name + ":" + v.arg
+}
+
+// omitEmpty skips the validators that follow it in the same chain or group when the value is blank.
+type omitEmpty struct{}
+
+func (omitEmpty) validate(*mappingState, interface{}, Parent) error {
+	return nil
+}
+
+func (omitEmpty) String() string {
+	return "omitempty"
+}
+
+// allOf is a group in parentheses, it fails with the error of its first failing node.
+type allOf struct {
+	text  string
+	nodes []validatorNode
+}
+
+func (v allOf) validate(s *mappingState, value interface{}, parent Parent) error {
+	for _, node := range v.nodes {
+		if _, ok := node.(omitEmpty); ok && isBlank(value) {
+			return nil
+		}
+		if err := node.validate(s, value, parent); err != nil {
+			return fmt.Errorf("%s: %w", node, err)
+		}
+	}
+	return nil
+}
+
+func (v allOf) String() string {
+	return v.text
+}
+
+// anyOf is a list of alternatives separated by '|', it passes as soon as one alternative passes.
+type anyOf struct {
+	text     string
+	branches []validatorNode
+}
+
+func (v anyOf) validate(s *mappingState, value interface{}, parent Parent) error {
+	failed := &AlternativesError{}
+	for _, branch := range v.branches {
+		err := branch.validate(s, value, parent)
+		if err == nil {
+			return nil
+		}
+		failed.Branches = append(failed.Branches, BranchError{Validator: branch.String(), Err: err})
+	}
+	return failed
+}
+
+func (v anyOf) String() string {
+	return v.text
+}
+
+// not is a node preceded by '!', it passes when the node fails.
+type not struct {
+	text string
+	node validatorNode
+}
+
+func (v not) validate(s *mappingState, value interface{}, parent Parent) error {
+	if v.node.validate(s, value, parent) == nil {
+		return fmt.Errorf("input must not satisfy %s", v.node)
+	}
+	return nil
+}
+
+func (v not) String() string {
+	return v.text
+}
+
+// validatorChain is the parsed form of a validators tag.
+type validatorChain struct {
+	// validators are applied to the value itself.
+	validators []validatorNode
+
+	// dive is set when the tag contains "dive", its validators are applied to each element of the value.
+	dive *diveChain
+}
+
+// diveChain holds the validators applied to the keys and elements of a slice, array or map.
+type diveChain struct {
+	// keys are applied to the keys of a map, declared between "keys" and "endkeys" right after "dive".
+	keys *validatorChain
+
+	// elements are applied to the elements of a slice or array and to the values of a map.
+	elements validatorChain
+}
+
+// runValidators executes every validator of the chain against the value and records each failure in the state.
+// When the chain dives, the element validators are run for every element with the index or key appended to the path.
+// It reports whether all validators passed.
+func (s *mappingState) runValidators(path string, value interface{}, chain validatorChain, parent Parent) bool {
+	valid := true
+	for _, validator := range chain.validators {
+		// omitempty also skips the validators of the elements
+		if _, ok := validator.(omitEmpty); ok && isBlank(value) {
+			return valid
+		}
+		if err := validator.validate(s, value, parent); err != nil {
+			s.addValidationError(path, validator, value, err)
+			valid = false
+		}
+	}
+
+	if chain.dive != nil && !s.runDive(path, value, chain.dive, parent) {
+		valid = false
+	}
+	return valid
+}
+
+// runDive runs the dive validators against each key and element of a slice, array or map.
+func (s *mappingState) runDive(path string, value interface{}, dive *diveChain, parent Parent) bool {
+	collection := reflect.ValueOf(value)
+	for collection.Kind() == reflect.Ptr || collection.Kind() == reflect.Interface {
+		if collection.IsNil() {
+			return true
+		}
+		collection = collection.Elem()
+	}
+
+	valid := true
+	switch collection.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < collection.Len(); i++ {
+			if !s.runValidators(indexPath(path, i), collection.Index(i).Interface(), dive.elements, parent) {
+				valid = false
+			}
+		}
+	case reflect.Map:
+		keys := collection.MapKeys()
+		// Sort the keys so errors are reported in a stable order
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		for _, key := range keys {
+			keyPath := fmt.Sprintf("%s[%v]", path, key.Interface())
+			if dive.keys != nil {
+				// Key errors share the path of the entry, flag them so they can be told apart from value errors
+				start := len(s.errs)
+				if !s.runValidators(keyPath, key.Interface(), *dive.keys, parent) {
+					valid = false
+				}
+				for _, fieldErr := range s.errs[start:] {
+					fieldErr.Key = true
+				}
+			}
+			if !s.runValidators(keyPath, collection.MapIndex(key).Interface(), dive.elements, parent) {
+				valid = false
+			}
+		}
+	default:
+		err := fmt.Errorf("dive can only be used on slices, arrays and maps, got %s", collection.Kind())
+		s.addValidationError(path, fieldValidator{name: "dive"}, value, err)
+		valid = false
+	}
+	return valid
+}
+
+// parseFieldValidators parses a comma-separated list of validators with optional arguments, e.g. "required,minLength:5".
+// Validators can be combined with '|', '!' and parentheses, see parseTagExpression. "omitempty" skips the validators
+// after it when the value is blank. Validators after "dive" are applied to each element,
+// "keys,...,endkeys" right after "dive" are applied to map keys.
+func (m *Mapper) parseFieldValidators(validatorSpec string) (validatorChain, error) {
+	terms, err := parseTagExpression(validatorSpec)
+	if err != nil {
+		return validatorChain{}, err
+	}
+	return m.parseValidatorChain(terms)
+}
+
+// parseValidatorChain parses the top-level terms of a validators tag, descending into a new chain at each "dive".
+func (m *Mapper) parseValidatorChain(terms []tagExpr) (validatorChain, error) {
+	var chain validatorChain
+	for i, term := range terms {
+		if term.isEntry("dive") {
+			dive, err := m.parseDive(terms[i+1:])
+			if err != nil {
+				return chain, err
+			}
+			chain.dive = dive
+			return chain, nil
+		}
+		if term.isEntry("keys") || term.isEntry("endkeys") {
+			return chain, fmt.Errorf("'%s' must directly follow 'dive'", term.entry.name)
+		}
+
+		validator, err := m.parseValidatorNode(term, true)
+		if err != nil {
+			return chain, err
+		}
+		chain.validators = append(chain.validators, validator)
+	}
+	return chain, nil
+}
+
+// parseValidatorNode resolves the validators of a term of a validators tag.
+// inSequence reports whether the term is part of a comma-separated list, where "omitempty" may be used.
+func (m *Mapper) parseValidatorNode(term tagExpr, inSequence bool) (validatorNode, error) {
+	switch term.op {
+	case exprAnd:
+		group := allOf{text: term.text}
+		for _, operand := range term.operands {
+			node, err := m.parseValidatorNode(operand, true)
+			if err != nil {
+				return nil, err
+			}
+			group.nodes = append(group.nodes, node)
+		}
+		return group, nil
+	case exprOr:
+		alternatives := anyOf{text: term.text}
+		for _, operand := range term.operands {
+			node, err := m.parseValidatorNode(operand, false)
+			if err != nil {
+				return nil, err
+			}
+			alternatives.branches = append(alternatives.branches, node)
+		}
+		return alternatives, nil
+	case exprNot:
+		node, err := m.parseValidatorNode(term.operands[0], false)
+		if err != nil {
+			return nil, err
+		}
+		return not{text: term.text, node: node}, nil
+	}
+
+	entry := term.entry
+	switch entry.name {
+	case "omitempty":
+		if !inSequence {
+			return nil, fmt.Errorf("'omitempty' cannot be combined with '|' or '!'")
+		}
+		return omitEmpty{}, nil
+	case "dive", "keys", "endkeys":
+		return nil, fmt.Errorf("'%s' cannot be used inside '|', '!' or parentheses", entry.name)
+	}
+
+	// Quoted arguments are kept as written, unquoted ones are trimmed
+	param := entry.arg
+	if entry.values == nil {
+		param = strings.TrimSpace(param)
+	}
+
+	validator, exists := m.lookupValidator(entry.name)
+	if !exists {
+		return nil, fmt.Errorf("validator '%s' not found", entry.name)
+	}
+	fn, err := validator.fn, error(nil)
+	if entry.values != nil && validator.values != nil {
+		fn, err = validator.values(entry.values)
+	} else if validator.checkArg != nil {
+		err = validator.checkArg(param)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid argument for validator '%s': %w", entry.name, err)
+	}
+	return fieldValidator{name: entry.name, arg: entry.text, param: param, fn: fn}, nil
+}
+
+// parseDive parses the terms following "dive", including an optional "keys,...,endkeys" section.
+func (m *Mapper) parseDive(terms []tagExpr) (*diveChain, error) {
+	dive := &diveChain{}
+	if len(terms) > 0 && terms[0].isEntry("keys") {
+		end := -1
+		for i, term := range terms {
+			if term.isEntry("endkeys") {
+				end = i
+				break
+			}
+		}
+		if end == -1 {
+			return nil, fmt.Errorf("'keys' must be closed with 'endkeys'")
+		}
+
+		keys, err := m.parseValidatorChain(terms[1:end])
+		if err != nil {
+			return nil, err
+		}
+		if keys.dive != nil {
+			return nil, fmt.Errorf("'dive' is not supported inside 'keys'")
+		}
+		dive.keys = &keys
+		terms = terms[end+1:]
+	}
+
+	elements, err := m.parseValidatorChain(terms)
+	if err != nil {
+		return nil, err
+	}
+	dive.elements = elements
+	return dive, nil
+}
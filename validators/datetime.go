@@ -0,0 +1,246 @@
+package validators
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timeLayouts maps the names that can be given instead of a layout to the layouts of the time package
+var timeLayouts = map[string]string{
+	"ansic":       time.ANSIC,
+	"unixdate":    time.UnixDate,
+	"rubydate":    time.RubyDate,
+	"rfc822":      time.RFC822,
+	"rfc822z":     time.RFC822Z,
+	"rfc850":      time.RFC850,
+	"rfc1123":     time.RFC1123,
+	"rfc1123z":    time.RFC1123Z,
+	"rfc3339":     time.RFC3339,
+	"rfc3339nano": time.RFC3339Nano,
+	"kitchen":     time.Kitchen,
+	"datetime":    time.DateTime,
+	"dateonly":    time.DateOnly,
+	"timeonly":    time.TimeOnly,
+}
+
+// boundLayouts are the layouts accepted for absolute bounds and for string inputs of the before, after and between validators
+var boundLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05", time.DateTime, time.DateOnly, "2006-01-02 15:04:05 MST"}
+
+// relativeTermRegex matches a single term of a relative bound such as "-18y" or "+30d"
+var relativeTermRegex = regexp.MustCompile(`^([+-])(\d+)(mo|y|w|d|h|m|s)`)
+
+// DateValidator checks if the input string is a valid date in YYYY-MM-DD format, or in the layout given as argument
+// (e.g., "02/01/2006" or "rfc3339"). time.Time values are always valid
+func DateValidator(input interface{}, layout string) error {
+	return validateLayout(input, layout, "date", "2006-01-02", "input is not a valid date, expected format YYYY-MM-DD")
+}
+
+// TimeValidator checks if the input string is a valid time in HH:MM:SS format, or in the layout given as argument
+// (e.g., "15:04" or "kitchen"). time.Time values are always valid
+func TimeValidator(input interface{}, layout string) error {
+	return validateLayout(input, layout, "time", "15:04:05", "input is not a valid time, expected format HH:MM:SS")
+}
+
+// DatetimeValidator checks if the input string is a valid datetime in YYYY-MM-DD HH:MM:SS with timezone format,
+// or in the layout given as argument (e.g., "rfc3339"). time.Time values are always valid
+func DatetimeValidator(input interface{}, layout string) error {
+	return validateLayout(input, layout, "datetime", "2006-01-02 15:04:05 MST", "input is not a valid datetime with timezone, expected format YYYY-MM-DD HH:MM:SS MST")
+}
+
+// BeforeValidator checks if the input is a time before the bound given as argument, see ParseTimeBound for the format.
+// The input is a time.Time or a string in RFC 3339 or YYYY-MM-DD[ HH:MM:SS] format
+func BeforeValidator(input interface{}, bound string) error {
+	value, ok, err := getTime(input)
+	if !ok || err != nil {
+		return err
+	}
+	limit, err := ParseTimeBound(bound, time.Now())
+	if err != nil {
+		return err
+	}
+	if !value.Before(limit) {
+		return fmt.Errorf("input must be before %s", bound)
+	}
+	return nil
+}
+
+// AfterValidator checks if the input is a time after the bound given as argument, see ParseTimeBound for the format.
+// The input is a time.Time or a string in RFC 3339 or YYYY-MM-DD[ HH:MM:SS] format
+func AfterValidator(input interface{}, bound string) error {
+	value, ok, err := getTime(input)
+	if !ok || err != nil {
+		return err
+	}
+	limit, err := ParseTimeBound(bound, time.Now())
+	if err != nil {
+		return err
+	}
+	if !value.After(limit) {
+		return fmt.Errorf("input must be after %s", bound)
+	}
+	return nil
+}
+
+// BetweenValidator checks if the input is a time between two bounds, inclusive, given as two values
+// (e.g., between:'now-100y'|'now-18y' in a tag). The input is a time.Time or a string like for BeforeValidator
+func BetweenValidator(input interface{}, bounds string) error {
+	value, ok, err := getTime(input)
+	if !ok || err != nil {
+		return err
+	}
+	from, to, err := ParseTimeRange(bounds, time.Now())
+	if err != nil {
+		return err
+	}
+	if value.Before(from) || value.After(to) {
+		args := SplitArgs(bounds)
+		return fmt.Errorf("input must be between %s and %s", args[0], args[1])
+	}
+	return nil
+}
+
+// ParseTimeBound parses the bound of the before, after and between validators.
+// A bound is an absolute time in RFC 3339 or YYYY-MM-DD[ HH:MM:SS] format, or "now" followed by any number of
+// terms that add or subtract years (y), months (mo), weeks (w), days (d), hours (h), minutes (m) or seconds (s),
+// e.g. "now-18y" or "now+1d-12h". Relative bounds are resolved against the given time
+func ParseTimeBound(bound string, now time.Time) (time.Time, error) {
+	bound = strings.TrimSpace(bound)
+	if !strings.HasPrefix(bound, "now") {
+		for _, layout := range boundLayouts {
+			if t, err := time.Parse(layout, bound); err == nil {
+				return t, nil
+			}
+		}
+		return time.Time{}, fmt.Errorf("invalid time bound '%s', expected a date or an expression like 'now-18y'", bound)
+	}
+
+	t := now
+	for rest := bound[len("now"):]; rest != ""; {
+		match := relativeTermRegex.FindStringSubmatch(rest)
+		if match == nil {
+			return time.Time{}, fmt.Errorf("invalid time bound '%s', expected a date or an expression like 'now-18y'", bound)
+		}
+		rest = rest[len(match[0]):]
+
+		amount, err := strconv.Atoi(match[2])
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid time bound '%s': %w", bound, err)
+		}
+		if match[1] == "-" {
+			amount = -amount
+		}
+		switch match[3] {
+		case "y":
+			t = t.AddDate(amount, 0, 0)
+		case "mo":
+			t = t.AddDate(0, amount, 0)
+		case "w":
+			t = t.AddDate(0, 0, 7*amount)
+		case "d":
+			t = t.AddDate(0, 0, amount)
+		case "h":
+			t = t.Add(time.Duration(amount) * time.Hour)
+		case "m":
+			t = t.Add(time.Duration(amount) * time.Minute)
+		case "s":
+			t = t.Add(time.Duration(amount) * time.Second)
+		}
+	}
+	return t, nil
+}
+
+// ParseTimeRange parses the two bounds of the between validator, see ParseTimeBound for their format
+func ParseTimeRange(bounds string, now time.Time) (time.Time, time.Time, error) {
+	args := SplitArgs(bounds)
+	if len(args) != 2 {
+		return time.Time{}, time.Time{}, fmt.Errorf("between format is incorrect, must be two values, e.g. between:'now-100y'|'now-18y'")
+	}
+	from, err := ParseTimeBound(args[0], now)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	to, err := ParseTimeBound(args[1], now)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, fmt.Errorf("between format is incorrect, '%s' is before '%s'", args[1], args[0])
+	}
+	return from, to, nil
+}
+
+// validateLayout checks if the input string matches the layout, or the default layout when the layout is empty.
+// Named layouts such as "rfc3339" are resolved with timeLayouts
+func validateLayout(input interface{}, layout, kind, defaultLayout, defaultMessage string) error {
+	if isEmptyOrNull(input) {
+		return nil
+	}
+	if _, ok := dereference(input).(time.Time); ok {
+		return nil
+	}
+
+	str, ok := getString(input)
+	if !ok {
+		return fmt.Errorf("failed to map the input to a string")
+	}
+
+	if layout == "" {
+		if _, err := time.Parse(defaultLayout, str); err != nil {
+			return errors.New(defaultMessage)
+		}
+		return nil
+	}
+
+	name := layout
+	if named, ok := timeLayouts[strings.ToLower(layout)]; ok {
+		layout = named
+	}
+	if _, err := time.Parse(layout, str); err != nil {
+		return fmt.Errorf("input is not a valid %s, expected format %s", kind, name)
+	}
+	return nil
+}
+
+// getTime returns the time of a time.Time input or parses a string input with the bound layouts.
+// It reports false for empty inputs and zero times, which are not validated
+func getTime(input interface{}) (time.Time, bool, error) {
+	if isEmptyOrNull(input) {
+		return time.Time{}, false, nil
+	}
+
+	switch value := dereference(input).(type) {
+	case nil:
+		return time.Time{}, false, nil
+	case time.Time:
+		return value, !value.IsZero(), nil
+	case string:
+		for _, layout := range boundLayouts {
+			if t, err := time.Parse(layout, value); err == nil {
+				return t, true, nil
+			}
+		}
+		return time.Time{}, false, fmt.Errorf("input is not a valid date or time, expected RFC 3339 or YYYY-MM-DD format")
+	default:
+		return time.Time{}, false, fmt.Errorf("input must be a time or a string")
+	}
+}
+
+// dereference follows pointers and returns the value they point to, or nil for a nil pointer
+func dereference(input interface{}) interface{} {
+	value := reflect.ValueOf(input)
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	if !value.IsValid() {
+		return nil
+	}
+	return value.Interface()
+}
@@ -0,0 +1,307 @@
+package validators
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Regular expressions used by the financial validators, compiled once
+var (
+	bicRegex     = regexp.MustCompile(`^[A-Z]{4}[A-Z]{2}[A-Z0-9]{2}([A-Z0-9]{3})?$`)
+	decimalRegex = regexp.MustCompile(`^[+-]?(\d*)(?:\.(\d*))?$`)
+)
+
+// cardBrand describes the number prefixes and lengths of a card brand
+type cardBrand struct {
+	name     string
+	prefixes [][2]int // inclusive ranges of prefixes, compared with as many leading digits as the bounds have
+	lengths  []int
+}
+
+// cardBrands are checked in order, the first brand with a matching prefix and length wins
+var cardBrands = []cardBrand{
+	{name: "amex", prefixes: [][2]int{{34, 34}, {37, 37}}, lengths: []int{15}},
+	{name: "dinersclub", prefixes: [][2]int{{300, 305}, {36, 36}, {38, 39}}, lengths: []int{14, 15, 16, 17, 18, 19}},
+	{name: "jcb", prefixes: [][2]int{{3528, 3589}}, lengths: []int{16, 17, 18, 19}},
+	{name: "visa", prefixes: [][2]int{{4, 4}}, lengths: []int{13, 16, 19}},
+	{name: "mastercard", prefixes: [][2]int{{51, 55}, {2221, 2720}}, lengths: []int{16}},
+	{name: "discover", prefixes: [][2]int{{6011, 6011}, {644, 649}, {65, 65}}, lengths: []int{16, 17, 18, 19}},
+	{name: "unionpay", prefixes: [][2]int{{62, 62}}, lengths: []int{16, 17, 18, 19}},
+}
+
+// ibanLengths holds the length of the IBAN of every country that uses them
+var ibanLengths = map[string]int{
+	"AD": 24, "AE": 23, "AL": 28, "AT": 20, "AZ": 28, "BA": 20, "BE": 16, "BG": 22, "BH": 22, "BI": 27,
+	"BR": 29, "BY": 28, "CH": 21, "CR": 22, "CY": 28, "CZ": 24, "DE": 22, "DJ": 27, "DK": 18, "DO": 28,
+	"EE": 20, "EG": 29, "ES": 24, "FI": 18, "FK": 18, "FO": 18, "FR": 27, "GB": 22, "GE": 22, "GI": 23,
+	"GL": 18, "GR": 27, "GT": 28, "HR": 21, "HU": 28, "IE": 22, "IL": 23, "IQ": 23, "IS": 26, "IT": 27,
+	"JO": 30, "KW": 30, "KZ": 20, "LB": 28, "LC": 32, "LI": 21, "LT": 20, "LU": 20, "LV": 21, "LY": 25,
+	"MC": 27, "MD": 24, "ME": 22, "MK": 19, "MN": 20, "MR": 27, "MT": 31, "MU": 30, "NI": 28, "NL": 18,
+	"NO": 15, "OM": 23, "PK": 24, "PL": 28, "PS": 29, "PT": 25, "QA": 29, "RO": 24, "RS": 22, "RU": 33,
+	"SA": 24, "SC": 31, "SD": 18, "SE": 24, "SI": 19, "SK": 24, "SM": 27, "SO": 23, "ST": 25, "SV": 28,
+	"TL": 23, "TN": 24, "TR": 26, "UA": 29, "VA": 22, "VG": 24, "XK": 20, "YE": 30,
+}
+
+// currencyCodes holds the active ISO 4217 currency codes, including funds and precious metals
+var currencyCodes = toSet(strings.Fields(`
+	AED AFN ALL AMD ANG AOA ARS AUD AWG AZN BAM BBD BDT BGN BHD BIF BMD BND BOB BOV BRL BSD BTN BWP BYN BZD
+	CAD CDF CHE CHF CHW CLF CLP CNY COP COU CRC CUC CUP CVE CZK DJF DKK DOP DZD EGP ERN ETB EUR FJD FKP
+	GBP GEL GHS GIP GMD GNF GTQ GYD HKD HNL HTG HUF IDR ILS INR IQD IRR ISK JMD JOD JPY KES KGS KHR KMF
+	KPW KRW KWD KYD KZT LAK LBP LKR LRD LSL LYD MAD MDL MGA MKD MMK MNT MOP MRU MUR MVR MWK MXN MXV MYR
+	MZN NAD NGN NIO NOK NPR NZD OMR PAB PEN PGK PHP PKR PLN PYG QAR RON RSD RUB RWF SAR SBD SCR SDG SEK
+	SGD SHP SLE SLL SOS SRD SSP STN SVC SYP SZL THB TJS TMT TND TOP TRY TTD TWD TZS UAH UGX USD USN UYI
+	UYU UYW UZS VED VES VND VUV WST XAF XAG XAU XBA XBB XBC XBD XCD XCG XDR XOF XPD XPF XPT XSU XUA YER
+	ZAR ZMW ZWG ZWL
+`))
+
+// CreditCardValidator checks if the input string is a card number with a valid Luhn checksum, spaces and dashes are ignored.
+// The optional argument restricts the brands (e.g., "visa" or "visa|mastercard"), see CardBrand for the names
+func CreditCardValidator(input interface{}, brands string) error {
+	if isEmptyOrNull(input) {
+		return nil
+	}
+
+	str, ok := getString(input)
+	if !ok {
+		return fmt.Errorf("failed to map the input to a string")
+	}
+	number := strings.NewReplacer(" ", "", "-", "").Replace(str)
+	if len(number) < 12 || len(number) > 19 || !isDigits(number) || !luhnValid(number) {
+		return fmt.Errorf("input is not a valid credit card number")
+	}
+
+	if strings.TrimSpace(brands) == "" {
+		return nil
+	}
+	brand := CardBrand(number)
+	for _, allowed := range SplitArgs(brands) {
+		if brand != "" && strings.EqualFold(brand, strings.TrimSpace(allowed)) {
+			return nil
+		}
+	}
+	return fmt.Errorf("input must be a card of the following brands: %s", brands)
+}
+
+// CardBrand returns the brand of a card number: amex, dinersclub, discover, jcb, mastercard, unionpay or visa.
+// It returns an empty string when the brand is unknown
+func CardBrand(number string) string {
+	number = strings.NewReplacer(" ", "", "-", "").Replace(number)
+	if !isDigits(number) {
+		return ""
+	}
+
+	for _, brand := range cardBrands {
+		if !containsInt(brand.lengths, len(number)) {
+			continue
+		}
+		for _, prefix := range brand.prefixes {
+			digits := len(strconv.Itoa(prefix[0]))
+			if len(number) < digits {
+				continue
+			}
+			value, _ := strconv.Atoi(number[:digits])
+			if value >= prefix[0] && value <= prefix[1] {
+				return brand.name
+			}
+		}
+	}
+	return ""
+}
+
+// IbanValidator checks if the input string is an IBAN with the length of its country and a valid mod-97 checksum,
+// spaces are ignored
+func IbanValidator(input interface{}, _ string) error {
+	if isEmptyOrNull(input) {
+		return nil
+	}
+
+	str, ok := getString(input)
+	if !ok {
+		return fmt.Errorf("failed to map the input to a string")
+	}
+	iban := strings.ToUpper(strings.ReplaceAll(str, " ", ""))
+	if len(iban) < 4 {
+		return fmt.Errorf("input is not a valid IBAN")
+	}
+
+	length, ok := ibanLengths[iban[:2]]
+	if !ok {
+		return fmt.Errorf("input is not a valid IBAN, unknown country code %s", iban[:2])
+	}
+	if len(iban) != length {
+		return fmt.Errorf("input is not a valid IBAN, expected %d characters for %s", length, iban[:2])
+	}
+
+	// Move the country code and check digits to the end and replace letters with numbers, A = 10 ... Z = 35
+	var digits strings.Builder
+	for _, c := range iban[4:] + iban[:4] {
+		switch {
+		case c >= '0' && c <= '9':
+			digits.WriteRune(c)
+		case c >= 'A' && c <= 'Z':
+			digits.WriteString(strconv.Itoa(int(c-'A') + 10))
+		default:
+			return fmt.Errorf("input is not a valid IBAN")
+		}
+	}
+	number, _ := new(big.Int).SetString(digits.String(), 10)
+	if new(big.Int).Mod(number, big.NewInt(97)).Int64() != 1 {
+		return fmt.Errorf("input is not a valid IBAN, the checksum does not match")
+	}
+	return nil
+}
+
+// BicValidator checks if the input string is a BIC (SWIFT code) of 8 or 11 characters
+func BicValidator(input interface{}, _ string) error {
+	if isEmptyOrNull(input) {
+		return nil
+	}
+
+	str, ok := getString(input)
+	if !ok {
+		return fmt.Errorf("failed to map the input to a string")
+	}
+	if !bicRegex.MatchString(str) {
+		return fmt.Errorf("input is not a valid BIC")
+	}
+	return nil
+}
+
+// CurrencyValidator checks if the input string is an active ISO 4217 currency code in uppercase (e.g., "EUR")
+func CurrencyValidator(input interface{}, _ string) error {
+	if isEmptyOrNull(input) {
+		return nil
+	}
+
+	str, ok := getString(input)
+	if !ok {
+		return fmt.Errorf("failed to map the input to a string")
+	}
+	if !currencyCodes[str] {
+		return fmt.Errorf("input is not a valid ISO 4217 currency code")
+	}
+	return nil
+}
+
+// DecimalValidator checks if a string or number has at most P digits of which at most S are fractional digits.
+// The argument is "P,S" (written as decimal:'10,2' in a tag) or "P:S", leading zeros of the integer part are not counted
+func DecimalValidator(input interface{}, precisionAndScale string) error {
+	if isEmptyOrNull(input) {
+		return nil
+	}
+
+	precision, scale, err := parsePrecisionAndScale(precisionAndScale)
+	if err != nil {
+		return err
+	}
+
+	var str string
+	value := reflect.ValueOf(input)
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	switch value.Kind() {
+	case reflect.String:
+		str = strings.TrimSpace(value.String())
+	case reflect.Float32, reflect.Float64:
+		str = strconv.FormatFloat(value.Float(), 'f', -1, value.Type().Bits())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		str = strconv.FormatInt(value.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		str = strconv.FormatUint(value.Uint(), 10)
+	default:
+		return fmt.Errorf("input must be a string or a number")
+	}
+
+	parts := decimalRegex.FindStringSubmatch(str)
+	if parts == nil || parts[1]+parts[2] == "" {
+		return fmt.Errorf("input is not a valid decimal number")
+	}
+	integerDigits := len(strings.TrimLeft(parts[1], "0"))
+	fractionDigits := len(parts[2])
+	if fractionDigits > scale {
+		return fmt.Errorf("input must have at most %d fractional digits", scale)
+	}
+	if integerDigits+fractionDigits > precision {
+		return fmt.Errorf("input must have at most %d digits", precision)
+	}
+	return nil
+}
+
+// parsePrecisionAndScale parses the "P,S" argument of the decimal validator
+func parsePrecisionAndScale(arg string) (int, int, error) {
+	values := SplitArgs(arg)
+	if len(values) != 2 {
+		values = strings.FieldsFunc(arg, func(r rune) bool { return r == ',' || r == ':' })
+	}
+	if len(values) != 2 {
+		return 0, 0, fmt.Errorf("decimal format is incorrect, must be 'precision,scale'")
+	}
+
+	precision, err := strconv.Atoi(strings.TrimSpace(values[0]))
+	if err != nil || precision < 1 {
+		return 0, 0, fmt.Errorf("failed to parse precision: %s", values[0])
+	}
+	scale, err := strconv.Atoi(strings.TrimSpace(values[1]))
+	if err != nil || scale < 0 || scale > precision {
+		return 0, 0, fmt.Errorf("failed to parse scale: %s", values[1])
+	}
+	return precision, scale, nil
+}
+
+// luhnValid reports whether the digits have a valid Luhn checksum
+func luhnValid(number string) bool {
+	sum := 0
+	double := false
+	for i := len(number) - 1; i >= 0; i-- {
+		digit := int(number[i] - '0')
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// isDigits reports whether the string is not empty and contains only ASCII digits
+func isDigits(str string) bool {
+	if str == "" {
+		return false
+	}
+	for i := 0; i < len(str); i++ {
+		if str[i] < '0' || str[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// containsInt reports whether the value is in the list
+func containsInt(list []int, value int) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// toSet turns a list of strings into a set
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[value] = true
+	}
+	return set
+}
@@ -0,0 +1,207 @@
+package validators
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Regular expressions used by the identifier validators, compiled once
+var (
+	uuidRegex    = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	ulidRegex    = regexp.MustCompile(`^[0-7][0-9A-HJKMNP-TV-Za-hjkmnp-tv-z]{25}$`)
+	hexRegex     = regexp.MustCompile(`^[0-9a-fA-F]+$`)
+	mongoIdRegex = regexp.MustCompile(`^[0-9a-fA-F]{24}$`)
+	// semverRegex is the expression recommended by semver.org
+	semverRegex = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+)
+
+// UuidValidator checks if the input string is a UUID, the optional argument is the required version (e.g., "4")
+func UuidValidator(input interface{}, version string) error {
+	if isEmptyOrNull(input) {
+		return nil
+	}
+
+	str, ok := getString(input)
+	if !ok {
+		return fmt.Errorf("failed to map the input to a string")
+	}
+	if !uuidRegex.MatchString(str) {
+		return fmt.Errorf("input is not a valid UUID")
+	}
+
+	version = strings.TrimSpace(version)
+	if version == "" {
+		return nil
+	}
+	if len(version) != 1 || version[0] < '1' || version[0] > '8' {
+		return fmt.Errorf("uuid version must be between 1 and 8, got '%s'", version)
+	}
+	// The version is the first digit of the third group, the variant must be the one of RFC 4122
+	if str[14] != version[0] || !strings.ContainsRune("89abAB", rune(str[19])) {
+		return fmt.Errorf("input is not a valid version %s UUID", version)
+	}
+	return nil
+}
+
+// UlidValidator checks if the input string is a ULID
+func UlidValidator(input interface{}, _ string) error {
+	if isEmptyOrNull(input) {
+		return nil
+	}
+
+	str, ok := getString(input)
+	if !ok {
+		return fmt.Errorf("failed to map the input to a string")
+	}
+	if !ulidRegex.MatchString(str) {
+		return fmt.Errorf("input is not a valid ULID")
+	}
+	return nil
+}
+
+// HexValidator checks if the input string contains only hexadecimal digits
+func HexValidator(input interface{}, _ string) error {
+	if isEmptyOrNull(input) {
+		return nil
+	}
+
+	str, ok := getString(input)
+	if !ok {
+		return fmt.Errorf("failed to map the input to a string")
+	}
+	if !hexRegex.MatchString(str) {
+		return fmt.Errorf("input is not a valid hexadecimal string")
+	}
+	return nil
+}
+
+// Base64Validator checks if the input string is standard base64 with padding
+func Base64Validator(input interface{}, _ string) error {
+	if isEmptyOrNull(input) {
+		return nil
+	}
+
+	str, ok := getString(input)
+	if !ok {
+		return fmt.Errorf("failed to map the input to a string")
+	}
+	if _, err := base64.StdEncoding.DecodeString(str); err != nil {
+		return fmt.Errorf("input is not a valid base64 string")
+	}
+	return nil
+}
+
+// Base64UrlValidator checks if the input string is URL-safe base64, with or without padding
+func Base64UrlValidator(input interface{}, _ string) error {
+	if isEmptyOrNull(input) {
+		return nil
+	}
+
+	str, ok := getString(input)
+	if !ok {
+		return fmt.Errorf("failed to map the input to a string")
+	}
+	if !isBase64Url(str) {
+		return fmt.Errorf("input is not a valid base64url string")
+	}
+	return nil
+}
+
+// JwtValidator checks if the input string has the structure of a JWT: three base64url segments and a JSON header with an algorithm.
+// The signature is not verified
+func JwtValidator(input interface{}, _ string) error {
+	if isEmptyOrNull(input) {
+		return nil
+	}
+
+	str, ok := getString(input)
+	if !ok {
+		return fmt.Errorf("failed to map the input to a string")
+	}
+
+	segments := strings.Split(str, ".")
+	if len(segments) != 3 || segments[0] == "" || segments[1] == "" {
+		return fmt.Errorf("input is not a valid JWT, expected three segments separated by dots")
+	}
+
+	headerJson, err := base64.RawURLEncoding.DecodeString(segments[0])
+	if err != nil {
+		return fmt.Errorf("input is not a valid JWT, the header is not base64url encoded")
+	}
+	var header map[string]interface{}
+	if err := json.Unmarshal(headerJson, &header); err != nil {
+		return fmt.Errorf("input is not a valid JWT, the header is not a JSON object")
+	}
+	if _, ok := header["alg"].(string); !ok {
+		return fmt.Errorf("input is not a valid JWT, the header has no algorithm")
+	}
+
+	if _, err := base64.RawURLEncoding.DecodeString(segments[1]); err != nil {
+		return fmt.Errorf("input is not a valid JWT, the payload is not base64url encoded")
+	}
+	if _, err := base64.RawURLEncoding.DecodeString(segments[2]); err != nil {
+		return fmt.Errorf("input is not a valid JWT, the signature is not base64url encoded")
+	}
+	return nil
+}
+
+// SemverValidator checks if the input string is a semantic version (e.g., "1.2.3-beta.1+build.5")
+func SemverValidator(input interface{}, _ string) error {
+	if isEmptyOrNull(input) {
+		return nil
+	}
+
+	str, ok := getString(input)
+	if !ok {
+		return fmt.Errorf("failed to map the input to a string")
+	}
+	if !semverRegex.MatchString(str) {
+		return fmt.Errorf("input is not a valid semantic version")
+	}
+	return nil
+}
+
+// JsonValidator checks if the input string is valid JSON
+func JsonValidator(input interface{}, _ string) error {
+	if isEmptyOrNull(input) {
+		return nil
+	}
+
+	str, ok := getString(input)
+	if !ok {
+		return fmt.Errorf("failed to map the input to a string")
+	}
+	if !json.Valid([]byte(str)) {
+		return fmt.Errorf("input is not valid JSON")
+	}
+	return nil
+}
+
+// MongoIdValidator checks if the input string is a MongoDB ObjectId of 24 hexadecimal digits
+func MongoIdValidator(input interface{}, _ string) error {
+	if isEmptyOrNull(input) {
+		return nil
+	}
+
+	str, ok := getString(input)
+	if !ok {
+		return fmt.Errorf("failed to map the input to a string")
+	}
+	if !mongoIdRegex.MatchString(str) {
+		return fmt.Errorf("input is not a valid MongoDB ObjectId")
+	}
+	return nil
+}
+
+// isBase64Url reports whether the string is URL-safe base64, with or without padding
+func isBase64Url(str string) bool {
+	if strings.HasSuffix(str, "=") {
+		_, err := base64.URLEncoding.DecodeString(str)
+		return err == nil
+	}
+	_, err := base64.RawURLEncoding.DecodeString(str)
+	return err == nil
+}
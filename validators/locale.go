@@ -0,0 +1,236 @@
+package validators
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	_ "time/tzdata" // Embedded timezone database, used by time.LoadLocation when the system has none
+)
+
+// countryCodes holds every ISO 3166-1 country as an alpha-2 code followed by its alpha-3 code
+var countryCodes = strings.Fields(`
+	AD AND  AE ARE  AF AFG  AG ATG  AI AIA  AL ALB  AM ARM  AO AGO  AQ ATA  AR ARG  AS ASM  AT AUT
+	AU AUS  AW ABW  AX ALA  AZ AZE  BA BIH  BB BRB  BD BGD  BE BEL  BF BFA  BG BGR  BH BHR  BI BDI
+	BJ BEN  BL BLM  BM BMU  BN BRN  BO BOL  BQ BES  BR BRA  BS BHS  BT BTN  BV BVT  BW BWA  BY BLR
+	BZ BLZ  CA CAN  CC CCK  CD COD  CF CAF  CG COG  CH CHE  CI CIV  CK COK  CL CHL  CM CMR  CN CHN
+	CO COL  CR CRI  CU CUB  CV CPV  CW CUW  CX CXR  CY CYP  CZ CZE  DE DEU  DJ DJI  DK DNK  DM DMA
+	DO DOM  DZ DZA  EC ECU  EE EST  EG EGY  EH ESH  ER ERI  ES ESP  ET ETH  FI FIN  FJ FJI  FK FLK
+	FM FSM  FO FRO  FR FRA  GA GAB  GB GBR  GD GRD  GE GEO  GF GUF  GG GGY  GH GHA  GI GIB  GL GRL
+	GM GMB  GN GIN  GP GLP  GQ GNQ  GR GRC  GS SGS  GT GTM  GU GUM  GW GNB  GY GUY  HK HKG  HM HMD
+	HN HND  HR HRV  HT HTI  HU HUN  ID IDN  IE IRL  IL ISR  IM IMN  IN IND  IO IOT  IQ IRQ  IR IRN
+	IS ISL  IT ITA  JE JEY  JM JAM  JO JOR  JP JPN  KE KEN  KG KGZ  KH KHM  KI KIR  KM COM  KN KNA
+	KP PRK  KR KOR  KW KWT  KY CYM  KZ KAZ  LA LAO  LB LBN  LC LCA  LI LIE  LK LKA  LR LBR  LS LSO
+	LT LTU  LU LUX  LV LVA  LY LBY  MA MAR  MC MCO  MD MDA  ME MNE  MF MAF  MG MDG  MH MHL  MK MKD
+	ML MLI  MM MMR  MN MNG  MO MAC  MP MNP  MQ MTQ  MR MRT  MS MSR  MT MLT  MU MUS  MV MDV  MW MWI
+	MX MEX  MY MYS  MZ MOZ  NA NAM  NC NCL  NE NER  NF NFK  NG NGA  NI NIC  NL NLD  NO NOR  NP NPL
+	NR NRU  NU NIU  NZ NZL  OM OMN  PA PAN  PE PER  PF PYF  PG PNG  PH PHL  PK PAK  PL POL  PM SPM
+	PN PCN  PR PRI  PS PSE  PT PRT  PW PLW  PY PRY  QA QAT  RE REU  RO ROU  RS SRB  RU RUS  RW RWA
+	SA SAU  SB SLB  SC SYC  SD SDN  SE SWE  SG SGP  SH SHN  SI SVN  SJ SJM  SK SVK  SL SLE  SM SMR
+	SN SEN  SO SOM  SR SUR  SS SSD  ST STP  SV SLV  SX SXM  SY SYR  SZ SWZ  TC TCA  TD TCD  TF ATF
+	TG TGO  TH THA  TJ TJK  TK TKL  TL TLS  TM TKM  TN TUN  TO TON  TR TUR  TT TTO  TV TUV  TW TWN
+	TZ TZA  UA UKR  UG UGA  UM UMI  US USA  UY URY  UZ UZB  VA VAT  VC VCT  VE VEN  VG VGB  VI VIR
+	VN VNM  VU VUT  WF WLF  WS WSM  YE YEM  YT MYT  ZA ZAF  ZM ZMB  ZW ZWE
+`)
+
+// Sets of the alpha-2 and alpha-3 country codes
+var (
+	countryAlpha2 = map[string]bool{}
+	countryAlpha3 = map[string]bool{}
+)
+
+func init() {
+	for i := 0; i < len(countryCodes); i += 2 {
+		countryAlpha2[countryCodes[i]] = true
+		countryAlpha3[countryCodes[i+1]] = true
+	}
+}
+
+// languageTagRegex matches the structure of a BCP 47 language tag: language, extended language, script, region,
+// variants, extensions and private use subtags. Irregular grandfathered tags are not supported
+var languageTagRegex = regexp.MustCompile(`(?i)^(?:(?:[a-z]{2,3}(?:-[a-z]{3}){0,3}|[a-z]{4}|[a-z]{5,8})(?:-[a-z]{4})?(?:-(?:[a-z]{2}|\d{3}))?(?:-(?:[a-z\d]{5,8}|\d[a-z\d]{3}))*(?:-[a-wyz\d](?:-[a-z\d]{2,8})+)*(?:-x(?:-[a-z\d]{1,8})+)?|x(?:-[a-z\d]{1,8})+)$`)
+
+// postalCodeRegexes holds the postal code format of each supported country, codes are compared in uppercase
+var postalCodeRegexes = map[string]*regexp.Regexp{
+	"AR": regexp.MustCompile(`^([A-HJ-NP-Z]\d{4}[A-Z]{3}|\d{4})$`),
+	"AT": regexp.MustCompile(`^\d{4}$`),
+	"AU": regexp.MustCompile(`^\d{4}$`),
+	"BE": regexp.MustCompile(`^\d{4}$`),
+	"BG": regexp.MustCompile(`^\d{4}$`),
+	"BR": regexp.MustCompile(`^\d{5}-?\d{3}$`),
+	"CA": regexp.MustCompile(`^[ABCEGHJ-NPRSTVXY]\d[ABCEGHJ-NPRSTV-Z] ?\d[ABCEGHJ-NPRSTV-Z]\d$`),
+	"CH": regexp.MustCompile(`^\d{4}$`),
+	"CN": regexp.MustCompile(`^\d{6}$`),
+	"CZ": regexp.MustCompile(`^\d{3} ?\d{2}$`),
+	"DE": regexp.MustCompile(`^\d{5}$`),
+	"DK": regexp.MustCompile(`^\d{4}$`),
+	"EE": regexp.MustCompile(`^\d{5}$`),
+	"ES": regexp.MustCompile(`^\d{5}$`),
+	"FI": regexp.MustCompile(`^\d{5}$`),
+	"FR": regexp.MustCompile(`^\d{5}$`),
+	"GB": regexp.MustCompile(`^([A-Z]{1,2}\d[A-Z\d]? ?\d[A-Z]{2}|GIR ?0AA)$`),
+	"GR": regexp.MustCompile(`^\d{3} ?\d{2}$`),
+	"HR": regexp.MustCompile(`^\d{5}$`),
+	"HU": regexp.MustCompile(`^\d{4}$`),
+	"IE": regexp.MustCompile(`^[A-Z]\d[\dW] ?[0-9AC-FHKNPRTV-Y]{4}$`),
+	"IL": regexp.MustCompile(`^\d{7}$`),
+	"IN": regexp.MustCompile(`^[1-9]\d{5}$`),
+	"IS": regexp.MustCompile(`^\d{3}$`),
+	"IT": regexp.MustCompile(`^\d{5}$`),
+	"JP": regexp.MustCompile(`^\d{3}-?\d{4}$`),
+	"KR": regexp.MustCompile(`^\d{5}$`),
+	"LT": regexp.MustCompile(`^(LT-)?\d{5}$`),
+	"LU": regexp.MustCompile(`^(L-)?\d{4}$`),
+	"LV": regexp.MustCompile(`^(LV-)?\d{4}$`),
+	"MX": regexp.MustCompile(`^\d{5}$`),
+	"NL": regexp.MustCompile(`^\d{4} ?[A-Z]{2}$`),
+	"NO": regexp.MustCompile(`^\d{4}$`),
+	"NZ": regexp.MustCompile(`^\d{4}$`),
+	"PL": regexp.MustCompile(`^\d{2}-\d{3}$`),
+	"PT": regexp.MustCompile(`^\d{4}-\d{3}$`),
+	"RO": regexp.MustCompile(`^\d{6}$`),
+	"RU": regexp.MustCompile(`^\d{6}$`),
+	"SE": regexp.MustCompile(`^\d{3} ?\d{2}$`),
+	"SG": regexp.MustCompile(`^\d{6}$`),
+	"SI": regexp.MustCompile(`^\d{4}$`),
+	"SK": regexp.MustCompile(`^\d{3} ?\d{2}$`),
+	"TR": regexp.MustCompile(`^\d{5}$`),
+	"UA": regexp.MustCompile(`^\d{5}$`),
+	"US": regexp.MustCompile(`^\d{5}(-\d{4})?$`),
+	"ZA": regexp.MustCompile(`^\d{4}$`),
+}
+
+// CountryValidator checks if the input string is an ISO 3166-1 country code in uppercase,
+// alpha-2 by default or alpha-3 with the argument "alpha3"
+func CountryValidator(input interface{}, format string) error {
+	if isEmptyOrNull(input) {
+		return nil
+	}
+
+	str, ok := getString(input)
+	if !ok {
+		return fmt.Errorf("failed to map the input to a string")
+	}
+	switch strings.TrimSpace(format) {
+	case "", "alpha2":
+		if !countryAlpha2[str] {
+			return fmt.Errorf("input is not a valid ISO 3166-1 alpha-2 country code")
+		}
+	case "alpha3":
+		if !countryAlpha3[str] {
+			return fmt.Errorf("input is not a valid ISO 3166-1 alpha-3 country code")
+		}
+	default:
+		return fmt.Errorf("country format must be 'alpha2' or 'alpha3', got '%s'", format)
+	}
+	return nil
+}
+
+// LanguageValidator checks if the input string has the structure of a BCP 47 language tag (e.g., "en", "pt-BR" or "zh-Hant-TW")
+func LanguageValidator(input interface{}, _ string) error {
+	if isEmptyOrNull(input) {
+		return nil
+	}
+
+	str, ok := getString(input)
+	if !ok {
+		return fmt.Errorf("failed to map the input to a string")
+	}
+	if !languageTagRegex.MatchString(str) {
+		return fmt.Errorf("input is not a valid BCP 47 language tag")
+	}
+	return nil
+}
+
+// TimezoneValidator checks if the input string is an IANA timezone name (e.g., "Europe/Berlin").
+// The timezone database is embedded, so it works on systems without one
+func TimezoneValidator(input interface{}, _ string) error {
+	if isEmptyOrNull(input) {
+		return nil
+	}
+
+	str, ok := getString(input)
+	if !ok {
+		return fmt.Errorf("failed to map the input to a string")
+	}
+	// LoadLocation also accepts "Local", which is not a timezone name
+	if _, err := time.LoadLocation(str); err != nil || str == "Local" {
+		return fmt.Errorf("input is not a valid IANA timezone")
+	}
+	return nil
+}
+
+// LatitudeValidator checks if the input is a number or numeric string between -90 and 90
+func LatitudeValidator(input interface{}, _ string) error {
+	if isEmptyOrNull(input) {
+		return nil
+	}
+
+	number, ok := getNumber(input)
+	if !ok || number < -90 || number > 90 {
+		return fmt.Errorf("input must be a latitude between -90 and 90")
+	}
+	return nil
+}
+
+// LongitudeValidator checks if the input is a number or numeric string between -180 and 180
+func LongitudeValidator(input interface{}, _ string) error {
+	if isEmptyOrNull(input) {
+		return nil
+	}
+
+	number, ok := getNumber(input)
+	if !ok || number < -180 || number > 180 {
+		return fmt.Errorf("input must be a longitude between -180 and 180")
+	}
+	return nil
+}
+
+// PostalCodeValidator checks if the input string is a postal code of the country given as argument (e.g., "DE"),
+// see PostalCodeCountries for the supported countries
+func PostalCodeValidator(input interface{}, country string) error {
+	if isEmptyOrNull(input) {
+		return nil
+	}
+
+	postalCodeRegex, err := lookupPostalCode(country)
+	if err != nil {
+		return err
+	}
+
+	str, ok := getString(input)
+	if !ok {
+		return fmt.Errorf("failed to map the input to a string")
+	}
+	if !postalCodeRegex.MatchString(strings.ToUpper(strings.TrimSpace(str))) {
+		return fmt.Errorf("input is not a valid postal code for %s", strings.ToUpper(strings.TrimSpace(country)))
+	}
+	return nil
+}
+
+// CheckPostalCodeCountry checks if postal codes of the country given as argument (e.g., "DE") are supported
+func CheckPostalCodeCountry(country string) error {
+	_, err := lookupPostalCode(country)
+	return err
+}
+
+// lookupPostalCode returns the postal code format of the country, ignoring case and surrounding spaces
+func lookupPostalCode(country string) (*regexp.Regexp, error) {
+	country = strings.ToUpper(strings.TrimSpace(country))
+	postalCodeRegex, ok := postalCodeRegexes[country]
+	if !ok {
+		return nil, fmt.Errorf("postal codes of country '%s' are not supported", country)
+	}
+	return postalCodeRegex, nil
+}
+
+// PostalCodeCountries returns the alpha-2 codes of the countries supported by PostalCodeValidator
+func PostalCodeCountries() []string {
+	countries := make([]string, 0, len(postalCodeRegexes))
+	for country := range postalCodeRegexes {
+		countries = append(countries, country)
+	}
+	sort.Strings(countries)
+	return countries
+}
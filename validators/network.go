@@ -0,0 +1,339 @@
+package validators
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// sharedAddressSpace is the carrier-grade NAT range of RFC 6598, which is not routable on the internet
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// thisNetwork is the 0.0.0.0/8 range of RFC 1122, connecting to it reaches the local host on some systems
+var thisNetwork = netip.MustParsePrefix("0.0.0.0/8")
+
+// nat64Prefix is the well-known NAT64 prefix of RFC 6052, its last 32 bits are the IPv4 address that is reached
+var nat64Prefix = netip.MustParsePrefix("64:ff9b::/96")
+
+// ipv4CompatiblePrefix holds the deprecated IPv4-compatible addresses of RFC 4291, e.g. ::127.0.0.1
+var ipv4CompatiblePrefix = netip.MustParsePrefix("::/96")
+
+// Ipv4Validator checks if the input string is an IPv4 address
+func Ipv4Validator(input interface{}, _ string) error {
+	if isEmptyOrNull(input) {
+		return nil
+	}
+
+	str, ok := getString(input)
+	if !ok {
+		return fmt.Errorf("failed to map the input to a string")
+	}
+	addr, err := netip.ParseAddr(str)
+	if err != nil || !addr.Is4() {
+		return fmt.Errorf("input is not a valid IPv4 address")
+	}
+	return nil
+}
+
+// Ipv6Validator checks if the input string is an IPv6 address
+func Ipv6Validator(input interface{}, _ string) error {
+	if isEmptyOrNull(input) {
+		return nil
+	}
+
+	str, ok := getString(input)
+	if !ok {
+		return fmt.Errorf("failed to map the input to a string")
+	}
+	addr, err := netip.ParseAddr(str)
+	if err != nil || !addr.Is6() {
+		return fmt.Errorf("input is not a valid IPv6 address")
+	}
+	return nil
+}
+
+// CidrValidator checks if the input string is an IPv4 or IPv6 network in CIDR notation (e.g., "10.0.0.0/8")
+func CidrValidator(input interface{}, _ string) error {
+	if isEmptyOrNull(input) {
+		return nil
+	}
+
+	str, ok := getString(input)
+	if !ok {
+		return fmt.Errorf("failed to map the input to a string")
+	}
+	if _, err := netip.ParsePrefix(str); err != nil {
+		return fmt.Errorf("input is not a valid CIDR notation")
+	}
+	return nil
+}
+
+// HostnameValidator checks if the input string is a hostname as defined by RFC 1123
+func HostnameValidator(input interface{}, _ string) error {
+	if isEmptyOrNull(input) {
+		return nil
+	}
+
+	str, ok := getString(input)
+	if !ok {
+		return fmt.Errorf("failed to map the input to a string")
+	}
+	if !isHostname(str) {
+		return fmt.Errorf("input is not a valid hostname")
+	}
+	return nil
+}
+
+// FqdnValidator checks if the input string is a fully qualified domain name, a trailing dot is allowed
+func FqdnValidator(input interface{}, _ string) error {
+	if isEmptyOrNull(input) {
+		return nil
+	}
+
+	str, ok := getString(input)
+	if !ok {
+		return fmt.Errorf("failed to map the input to a string")
+	}
+	if !isFqdn(str) {
+		return fmt.Errorf("input is not a valid fully qualified domain name")
+	}
+	return nil
+}
+
+// PortValidator checks if the input is a port number between 1 and 65535, for strings and integers
+func PortValidator(input interface{}, _ string) error {
+	if isEmptyOrNull(input) {
+		return nil
+	}
+
+	value := reflect.ValueOf(input)
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+
+	var port int64
+	switch value.Kind() {
+	case reflect.String:
+		if !isPort(value.String()) {
+			return fmt.Errorf("input must be a port between 1 and 65535")
+		}
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		port = value.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if value.Uint() > 65535 {
+			return fmt.Errorf("input must be a port between 1 and 65535")
+		}
+		port = int64(value.Uint())
+	default:
+		return fmt.Errorf("input must be a string or an integer")
+	}
+	if port < 1 || port > 65535 {
+		return fmt.Errorf("input must be a port between 1 and 65535")
+	}
+	return nil
+}
+
+// MacValidator checks if the input string is a MAC address (e.g., "00:1a:2b:3c:4d:5e")
+func MacValidator(input interface{}, _ string) error {
+	if isEmptyOrNull(input) {
+		return nil
+	}
+
+	str, ok := getString(input)
+	if !ok {
+		return fmt.Errorf("failed to map the input to a string")
+	}
+	if _, err := net.ParseMAC(str); err != nil {
+		return fmt.Errorf("input is not a valid MAC address")
+	}
+	return nil
+}
+
+// HostPortValidator checks if the input string is a host and a port (e.g., "example.com:443" or "[::1]:8080"),
+// the host must be a hostname or an IP address
+func HostPortValidator(input interface{}, _ string) error {
+	if isEmptyOrNull(input) {
+		return nil
+	}
+
+	str, ok := getString(input)
+	if !ok {
+		return fmt.Errorf("failed to map the input to a string")
+	}
+	host, port, err := net.SplitHostPort(str)
+	if err != nil || !isPort(port) {
+		return fmt.Errorf("input is not a valid host and port")
+	}
+	if _, err := netip.ParseAddr(host); err != nil && !isHostname(host) {
+		return fmt.Errorf("input is not a valid host and port")
+	}
+	return nil
+}
+
+// PublicUrlValidator checks if the input string is a URL whose host is not a loopback, private, link-local or unspecified address,
+// to prevent requests to internal services. Like UrlValidator it accepts allowed schemes as argument.
+// Hostnames are not resolved, so names pointing to internal addresses must still be rejected when connecting
+func PublicUrlValidator(input interface{}, schemes string) error {
+	if err := UrlValidator(input, schemes); err != nil || isEmptyOrNull(input) {
+		return err
+	}
+
+	str, _ := getString(input)
+	u, _ := url.ParseRequestURI(str)
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return fmt.Errorf("input must not point to a loopback address")
+	}
+
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		numeric, isNumeric, valid := parseNumericHost(host)
+		if !isNumeric {
+			return nil
+		}
+		if !valid {
+			return fmt.Errorf("input is not a valid URL")
+		}
+		addr = numeric
+	}
+	addr = embeddedIPv4(addr.Unmap())
+	switch {
+	case addr.IsLoopback():
+		return fmt.Errorf("input must not point to a loopback address")
+	case addr.IsPrivate(), sharedAddressSpace.Contains(addr):
+		return fmt.Errorf("input must not point to a private address")
+	case addr.IsLinkLocalUnicast(), addr.IsLinkLocalMulticast(), addr.IsInterfaceLocalMulticast():
+		return fmt.Errorf("input must not point to a link-local address")
+	case addr.IsUnspecified(), thisNetwork.Contains(addr), addr.IsMulticast():
+		return fmt.Errorf("input must not point to an unspecified or multicast address")
+	}
+	return nil
+}
+
+// embeddedIPv4 returns the IPv4 address embedded in a NAT64 or IPv4-compatible IPv6 address, since connecting to it
+// reaches that IPv4 address. Other addresses, including :: and ::1, are returned unchanged
+func embeddedIPv4(addr netip.Addr) netip.Addr {
+	if !nat64Prefix.Contains(addr) && !ipv4CompatiblePrefix.Contains(addr) {
+		return addr
+	}
+	if addr.IsUnspecified() || addr.IsLoopback() {
+		return addr
+	}
+	bytes := addr.As16()
+	return netip.AddrFrom4([4]byte(bytes[12:]))
+}
+
+// parseNumericHost parses a host made only of numeric labels the way inet_aton does, which is how resolvers, curl and
+// browsers read it, e.g. "127.1", "2130706433", "0x7f000001" or "0177.0.0.1" are all 127.0.0.1.
+// It reports whether the host is numeric, and whether it is a valid IPv4 address
+func parseNumericHost(host string) (netip.Addr, bool, bool) {
+	labels := strings.Split(host, ".")
+	values := make([]uint64, len(labels))
+	for i, label := range labels {
+		value, ok := parseNumericLabel(label)
+		if !ok {
+			return netip.Addr{}, false, false
+		}
+		values[i] = value
+	}
+	if len(labels) > 4 {
+		return netip.Addr{}, true, false
+	}
+
+	// Every label but the last is a single byte, the last one fills the remaining bytes
+	var address uint64
+	for _, value := range values[:len(values)-1] {
+		if value > 0xff {
+			return netip.Addr{}, true, false
+		}
+		address = address<<8 | value
+	}
+	remaining := 8 * uint(5-len(values))
+	last := values[len(values)-1]
+	if last >= 1<<remaining {
+		return netip.Addr{}, true, false
+	}
+	address = address<<remaining | last
+	return netip.AddrFrom4([4]byte{byte(address >> 24), byte(address >> 16), byte(address >> 8), byte(address)}), true, true
+}
+
+// parseNumericLabel parses a decimal, octal (leading 0) or hexadecimal (leading 0x) label of a numeric host
+func parseNumericLabel(label string) (uint64, bool) {
+	base := 10
+	switch {
+	case len(label) > 2 && (label[:2] == "0x" || label[:2] == "0X"):
+		label, base = label[2:], 16
+	case len(label) > 1 && label[0] == '0':
+		label, base = label[1:], 8
+	}
+	if label == "" || strings.TrimLeft(label, "0123456789abcdefABCDEF") != "" {
+		return 0, false
+	}
+	value, err := strconv.ParseUint(label, base, 64)
+	if err != nil {
+		// Digits that are out of range for the base make the label a name, too many digits make the host invalid
+		numErr, ok := err.(*strconv.NumError)
+		return 1 << 40, ok && numErr.Err == strconv.ErrRange
+	}
+	return value, true
+}
+
+// isHostname reports whether the string is a hostname as defined by RFC 1123
+func isHostname(str string) bool {
+	if len(str) == 0 || len(str) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(str, ".") {
+		if !isHostnameLabel(label) {
+			return false
+		}
+	}
+	return true
+}
+
+// isHostnameLabel reports whether the string is a label of 1 to 63 letters, digits and inner hyphens
+func isHostnameLabel(label string) bool {
+	if len(label) == 0 || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+		return false
+	}
+	for i := 0; i < len(label); i++ {
+		c := label[i]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+// isFqdn reports whether the string is a hostname with at least two labels and a top-level domain that is not numeric
+func isFqdn(str string) bool {
+	str = strings.TrimSuffix(str, ".")
+	labels := strings.Split(str, ".")
+	if len(labels) < 2 || !isHostname(str) {
+		return false
+	}
+	_, err := strconv.Atoi(labels[len(labels)-1])
+	return err != nil
+}
+
+// isPort reports whether the string is a decimal port number between 1 and 65535
+func isPort(str string) bool {
+	if len(str) == 0 || len(str) > 5 {
+		return false
+	}
+	for i := 0; i < len(str); i++ {
+		if str[i] < '0' || str[i] > '9' {
+			return false
+		}
+	}
+	port, _ := strconv.Atoi(str)
+	return port >= 1 && port <= 65535
+}
@@ -0,0 +1,260 @@
+package validators
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// GreaterThanValidator checks if a number is greater than the threshold given as argument
+func GreaterThanValidator(input interface{}, threshold string) error {
+	result, ok, err := compareNumber(input, threshold)
+	if !ok || err != nil {
+		return err
+	}
+	if result <= 0 {
+		return fmt.Errorf("input must be greater than %s", threshold)
+	}
+	return nil
+}
+
+// LessThanValidator checks if a number is less than the threshold given as argument
+func LessThanValidator(input interface{}, threshold string) error {
+	result, ok, err := compareNumber(input, threshold)
+	if !ok || err != nil {
+		return err
+	}
+	if result >= 0 {
+		return fmt.Errorf("input must be less than %s", threshold)
+	}
+	return nil
+}
+
+// GreaterThanOrEqualValidator checks if a number is greater than or equal to the threshold given as argument
+func GreaterThanOrEqualValidator(input interface{}, threshold string) error {
+	result, ok, err := compareNumber(input, threshold)
+	if !ok || err != nil {
+		return err
+	}
+	if result < 0 {
+		return fmt.Errorf("input must be greater than or equal to %s", threshold)
+	}
+	return nil
+}
+
+// LessThanOrEqualValidator checks if a number is less than or equal to the threshold given as argument
+func LessThanOrEqualValidator(input interface{}, threshold string) error {
+	result, ok, err := compareNumber(input, threshold)
+	if !ok || err != nil {
+		return err
+	}
+	if result > 0 {
+		return fmt.Errorf("input must be less than or equal to %s", threshold)
+	}
+	return nil
+}
+
+// RangeValidator checks if a number is between two numbers specified with a dash (e.g., "10-100"),
+// or as two values (e.g., range:'-10'|'10' in a tag) to allow negative bounds
+func RangeValidator(input interface{}, rangeStr string) error {
+	if isEmptyOrNull(input) {
+		return nil
+	}
+
+	number, ok := toRat(input)
+	if !ok {
+		return fmt.Errorf("input must be a number")
+	}
+
+	parts := splitValues(rangeStr, "-")
+	if len(parts) != 2 {
+		return fmt.Errorf("range format is incorrect, must be 'min-max'")
+	}
+
+	min, ok := parseRat(parts[0])
+	if !ok {
+		return fmt.Errorf("failed to parse minimum value: %s", parts[0])
+	}
+
+	max, ok := parseRat(parts[1])
+	if !ok {
+		return fmt.Errorf("failed to parse maximum value: %s", parts[1])
+	}
+
+	if min.Cmp(max) > 0 {
+		return fmt.Errorf("minimum value must be less than maximum value")
+	}
+
+	if number.Cmp(min) < 0 || number.Cmp(max) > 0 {
+		return fmt.Errorf("input must be between %s and %s", parts[0], parts[1])
+	}
+	return nil
+}
+
+// MultipleOfValidator checks if a number is a multiple of the number given as argument (e.g., "5" or "0.25")
+func MultipleOfValidator(input interface{}, divisor string) error {
+	if isEmptyOrNull(input) {
+		return nil
+	}
+
+	number, ok := toRat(input)
+	if !ok {
+		return fmt.Errorf("input must be a number")
+	}
+	d, ok := parseRat(divisor)
+	if !ok || d.Sign() == 0 {
+		return fmt.Errorf("multipleOf must be a number other than zero, got '%s'", divisor)
+	}
+
+	if !new(big.Rat).Quo(number, d).IsInt() {
+		return fmt.Errorf("input must be a multiple of %s", divisor)
+	}
+	return nil
+}
+
+// DigitLengthValidator checks if an integer has the number of digits given as argument, not counting the sign.
+// The argument is a number (e.g., "4") or a range specified with a dash (e.g., "4-6")
+func DigitLengthValidator(input interface{}, length string) error {
+	if isEmptyOrNull(input) {
+		return nil
+	}
+
+	number, ok := toRat(input)
+	if !ok || !number.IsInt() {
+		return fmt.Errorf("input must be an integer")
+	}
+
+	parts := splitValues(length, "-")
+	if len(parts) > 2 {
+		return fmt.Errorf("len format is incorrect, must be 'length' or 'min-max'")
+	}
+	min, minErr := parseLength(parts[0])
+	max, maxErr := parseLength(parts[len(parts)-1])
+	if minErr != nil || maxErr != nil || min > max {
+		return fmt.Errorf("len format is incorrect, must be 'length' or 'min-max'")
+	}
+
+	digits := len(new(big.Int).Abs(number.Num()).String())
+	if digits < min || digits > max {
+		if min == max {
+			return fmt.Errorf("input must have %d digits", min)
+		}
+		return fmt.Errorf("input must have between %d and %d digits", min, max)
+	}
+	return nil
+}
+
+// AllowNumericStrings wraps a numeric validator, such as GreaterThanValidator, so it also accepts strings that hold a number (e.g., "42")
+func AllowNumericStrings(validator func(input interface{}, arg string) error) func(input interface{}, arg string) error {
+	return func(input interface{}, arg string) error {
+		if str, ok := getString(input); ok && str != "" {
+			str = strings.TrimSpace(str)
+			if _, ok := parseRat(str); !ok {
+				return fmt.Errorf("input must be a number")
+			}
+			// Every numeric validator accepts a json.Number
+			input = json.Number(str)
+		}
+		return validator(input, arg)
+	}
+}
+
+// CompareNumbers compares two numbers of any type accepted by the numeric validators exactly and returns -1, 0 or 1.
+// It reports false when either value is not a number
+func CompareNumbers(a, b interface{}) (int, bool) {
+	numberA, ok := toRat(a)
+	if !ok {
+		return 0, false
+	}
+	numberB, ok := toRat(b)
+	if !ok {
+		return 0, false
+	}
+	return numberA.Cmp(numberB), true
+}
+
+// compareNumber compares the input with the threshold and returns -1, 0 or 1.
+// It reports false for empty inputs, which are not validated
+func compareNumber(input interface{}, threshold string) (int, bool, error) {
+	if isEmptyOrNull(input) {
+		return 0, false, nil
+	}
+
+	number, ok := toRat(input)
+	if !ok {
+		return 0, false, fmt.Errorf("input must be a number")
+	}
+	thresh, ok := parseRat(threshold)
+	if !ok {
+		return 0, false, fmt.Errorf("failed to convert threshold to a number: %s", threshold)
+	}
+	return number.Cmp(thresh), true, nil
+}
+
+// toRat converts a number of any numeric kind, a json.Number, or a big.Int, big.Float or big.Rat to an exact rational.
+// Integers are converted without going through float64, so large values keep their precision
+func toRat(input interface{}) (*big.Rat, bool) {
+	switch number := input.(type) {
+	case *big.Int:
+		if number == nil {
+			return nil, false
+		}
+		return new(big.Rat).SetInt(number), true
+	case *big.Float:
+		if number == nil || number.IsInf() {
+			return nil, false
+		}
+		rat, _ := number.Rat(nil)
+		return rat, true
+	case *big.Rat:
+		return number, number != nil
+	case big.Int:
+		return new(big.Rat).SetInt(&number), true
+	case big.Float:
+		return toRat(&number)
+	case big.Rat:
+		return &number, true
+	case json.Number:
+		return parseRat(string(number))
+	}
+
+	value := reflect.ValueOf(input)
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() {
+			return nil, false
+		}
+		return toRat(value.Elem().Interface())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Rat).SetInt64(value.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Rat).SetInt(new(big.Int).SetUint64(value.Uint())), true
+	case reflect.Float32:
+		// Floats are converted through their shortest decimal form, so 0.1 equals the threshold "0.1"
+		return parseRat(strconv.FormatFloat(value.Float(), 'g', -1, 32))
+	case reflect.Float64:
+		return parseRat(strconv.FormatFloat(value.Float(), 'g', -1, 64))
+	}
+	return nil, false
+}
+
+// parseRat parses a decimal number such as "10", "-2.5" or "1e3" exactly
+func parseRat(str string) (*big.Rat, bool) {
+	str = strings.TrimSpace(str)
+	if str == "" || strings.Contains(str, "/") {
+		return nil, false
+	}
+	return new(big.Rat).SetString(str)
+}
+
+// parseLength parses a non-negative number of digits
+func parseLength(str string) (int, error) {
+	number, ok := parseRat(str)
+	if !ok || !number.IsInt() || number.Sign() < 0 || !number.Num().IsInt64() {
+		return 0, fmt.Errorf("invalid length '%s'", str)
+	}
+	return int(number.Num().Int64()), nil
+}
@@ -0,0 +1,232 @@
+package validators
+
+import (
+	_ "embed"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// The rules of a password policy, reported in PasswordError.Rule
+const (
+	PasswordRuleMinLength = "minLength"
+	PasswordRuleUpper     = "upper"
+	PasswordRuleLower     = "lower"
+	PasswordRuleDigit     = "digit"
+	PasswordRuleSpecial   = "special"
+	PasswordRuleMaxRepeat = "maxRepeat"
+	PasswordRuleCommon    = "common"
+)
+
+// commonPasswordsFile is a list of frequently used passwords, one per line
+//
+//go:embed common_passwords.txt
+var commonPasswordsFile string
+
+// commonPasswords is built from commonPasswordsFile on first use
+var (
+	commonPasswords     PasswordSet
+	commonPasswordsOnce sync.Once
+)
+
+// PasswordList is a list of passwords that must not be used, e.g. a list of breached passwords
+type PasswordList interface {
+	// Contains reports whether the password is in the list
+	Contains(password string) bool
+}
+
+// PasswordSet is a PasswordList kept in memory, passwords are compared case-insensitively
+type PasswordSet map[string]struct{}
+
+// NewPasswordSet creates a PasswordSet containing the given passwords
+func NewPasswordSet(passwords ...string) PasswordSet {
+	set := make(PasswordSet, len(passwords))
+	for _, password := range passwords {
+		set[strings.ToLower(password)] = struct{}{}
+	}
+	return set
+}
+
+// Contains reports whether the password is in the set, ignoring case
+func (s PasswordSet) Contains(password string) bool {
+	_, ok := s[strings.ToLower(password)]
+	return ok
+}
+
+// CommonPasswords returns the embedded list of the most common passwords, used by the password validator by default
+func CommonPasswords() PasswordSet {
+	commonPasswordsOnce.Do(func() {
+		commonPasswords = NewPasswordSet(strings.Fields(commonPasswordsFile)...)
+	})
+	return commonPasswords
+}
+
+// PasswordPolicy describes the requirements of the password validator
+type PasswordPolicy struct {
+	// MinLength is the minimum number of characters, counted in runes
+	MinLength int
+	// RequireUpper, RequireLower, RequireDigit and RequireSpecial require at least one character of each class
+	RequireUpper   bool
+	RequireLower   bool
+	RequireDigit   bool
+	RequireSpecial bool
+	// MaxRepeat is the maximum number of identical characters in a row, 0 allows any number
+	MaxRepeat int
+	// Blocklist rejects the passwords it contains, nil disables the check
+	Blocklist PasswordList
+}
+
+// PasswordError reports the rule of a password policy that a password does not satisfy
+type PasswordError struct {
+	// Rule is one of the PasswordRule constants
+	Rule    string
+	Message string
+}
+
+// Error implements the error interface
+func (e *PasswordError) Error() string {
+	return e.Message
+}
+
+// DefaultPasswordPolicy returns the policy of the password validator: at least 8 characters of all four classes
+// and no password of the embedded common passwords list
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{
+		MinLength:      8,
+		RequireUpper:   true,
+		RequireLower:   true,
+		RequireDigit:   true,
+		RequireSpecial: true,
+		Blocklist:      CommonPasswords(),
+	}
+}
+
+// PasswordValidator checks the password against DefaultPasswordPolicy, adjusted by the argument, see ParsePasswordPolicy
+func PasswordValidator(input interface{}, options string) error {
+	return NewPasswordValidator(DefaultPasswordPolicy())(input, options)
+}
+
+// NewPasswordValidator creates a password validator for the policy, e.g. to use your own Blocklist.
+// The argument of the validator adjusts the policy, see ParsePasswordPolicy
+func NewPasswordValidator(policy PasswordPolicy) func(input interface{}, options string) error {
+	return func(input interface{}, options string) error {
+		if isEmptyOrNull(input) {
+			return nil
+		}
+
+		str, ok := getString(input)
+		if !ok {
+			return fmt.Errorf("failed to map the input to a string")
+		}
+		policy, err := ParsePasswordPolicy(options, policy)
+		if err != nil {
+			return err
+		}
+		return policy.Check(str)
+	}
+}
+
+// ParsePasswordPolicy adjusts the base policy with options separated by commas, e.g. "min=12,classes=uld,maxRepeat=3".
+// The options are min (the minimum length), classes (any of u, l, d and s for uppercase, lowercase, digit and special,
+// empty to require none), maxRepeat (0 for any number) and common (false to skip the Blocklist)
+func ParsePasswordPolicy(options string, base PasswordPolicy) (PasswordPolicy, error) {
+	policy := base
+	for _, part := range SplitArgs(options) {
+		for _, option := range strings.Split(part, ",") {
+			option = strings.TrimSpace(option)
+			if option == "" {
+				continue
+			}
+
+			key, value, found := strings.Cut(option, "=")
+			if !found {
+				return base, fmt.Errorf("invalid password option '%s', expected key=value", option)
+			}
+			key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+			switch key {
+			case "min":
+				length, err := strconv.Atoi(value)
+				if err != nil || length < 0 {
+					return base, fmt.Errorf("invalid password option '%s', min must be a positive number", option)
+				}
+				policy.MinLength = length
+			case "classes":
+				if strings.Trim(value, "ulds") != "" {
+					return base, fmt.Errorf("invalid password option '%s', classes must be any of u, l, d and s", option)
+				}
+				policy.RequireUpper = strings.Contains(value, "u")
+				policy.RequireLower = strings.Contains(value, "l")
+				policy.RequireDigit = strings.Contains(value, "d")
+				policy.RequireSpecial = strings.Contains(value, "s")
+			case "maxRepeat":
+				repeat, err := strconv.Atoi(value)
+				if err != nil || repeat < 0 {
+					return base, fmt.Errorf("invalid password option '%s', maxRepeat must be a positive number", option)
+				}
+				policy.MaxRepeat = repeat
+			case "common":
+				check, err := strconv.ParseBool(value)
+				if err != nil {
+					return base, fmt.Errorf("invalid password option '%s', common must be true or false", option)
+				}
+				if !check {
+					policy.Blocklist = nil
+				}
+			default:
+				return base, fmt.Errorf("unknown password option '%s'", key)
+			}
+		}
+	}
+	return policy, nil
+}
+
+// Check returns a PasswordError for the first rule of the policy that the password does not satisfy
+func (p PasswordPolicy) Check(password string) error {
+	if utf8.RuneCountInString(password) < p.MinLength {
+		return &PasswordError{Rule: PasswordRuleMinLength, Message: fmt.Sprintf("password must be at least %d characters long", p.MinLength)}
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSpecial bool
+	var previous rune
+	repeat := 0
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case !unicode.IsLetter(r) && !unicode.IsSpace(r):
+			hasSpecial = true
+		}
+
+		if r == previous {
+			repeat++
+		} else {
+			previous, repeat = r, 1
+		}
+		if p.MaxRepeat > 0 && repeat > p.MaxRepeat {
+			return &PasswordError{Rule: PasswordRuleMaxRepeat, Message: fmt.Sprintf("password must not contain more than %d identical characters in a row", p.MaxRepeat)}
+		}
+	}
+
+	switch {
+	case p.RequireUpper && !hasUpper:
+		return &PasswordError{Rule: PasswordRuleUpper, Message: "password must contain at least one uppercase letter"}
+	case p.RequireLower && !hasLower:
+		return &PasswordError{Rule: PasswordRuleLower, Message: "password must contain at least one lowercase letter"}
+	case p.RequireDigit && !hasDigit:
+		return &PasswordError{Rule: PasswordRuleDigit, Message: "password must contain at least one digit"}
+	case p.RequireSpecial && !hasSpecial:
+		return &PasswordError{Rule: PasswordRuleSpecial, Message: "password must contain at least one special character"}
+	}
+
+	if p.Blocklist != nil && p.Blocklist.Contains(password) {
+		return &PasswordError{Rule: PasswordRuleCommon, Message: "password is too common"}
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package validators
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MinRunesValidator checks if the input string has at least the specified number of characters, counted in runes
+func MinRunesValidator(input interface{}, length string) error {
+	str, minLength, ok, err := getStringAndLength(input, length)
+	if !ok || err != nil {
+		return err
+	}
+	if utf8.RuneCountInString(str) < minLength {
+		return fmt.Errorf("input does not meet the minimum length requirement, minimum length is %s", length)
+	}
+	return nil
+}
+
+// MaxRunesValidator checks if the input string has at most the specified number of characters, counted in runes
+func MaxRunesValidator(input interface{}, length string) error {
+	str, maxLength, ok, err := getStringAndLength(input, length)
+	if !ok || err != nil {
+		return err
+	}
+	if utf8.RuneCountInString(str) > maxLength {
+		return fmt.Errorf("input exceeds the maximum length requirement, maximum length is %s", length)
+	}
+	return nil
+}
+
+// ExactLengthValidator checks if the input string has exactly the specified number of characters, counted in runes
+func ExactLengthValidator(input interface{}, length string) error {
+	str, exactLength, ok, err := getStringAndLength(input, length)
+	if !ok || err != nil {
+		return err
+	}
+	if utf8.RuneCountInString(str) != exactLength {
+		return fmt.Errorf("input must be exactly %s characters long", length)
+	}
+	return nil
+}
+
+// AlphaValidator checks if the input string contains only ASCII letters
+func AlphaValidator(input interface{}, _ string) error {
+	return validateRunes(input, isAsciiLetter, "input must contain only letters")
+}
+
+// AlphanumericValidator checks if the input string contains only ASCII letters and digits
+func AlphanumericValidator(input interface{}, _ string) error {
+	return validateRunes(input, func(r rune) bool {
+		return isAsciiLetter(r) || isAsciiDigit(r)
+	}, "input must contain only letters and digits")
+}
+
+// AsciiValidator checks if the input string contains only ASCII characters
+func AsciiValidator(input interface{}, _ string) error {
+	return validateRunes(input, func(r rune) bool {
+		return r < utf8.RuneSelf
+	}, "input must contain only ASCII characters")
+}
+
+// PrintableValidator checks if the input string contains only printable characters, which excludes control characters
+// and whitespace other than the space
+func PrintableValidator(input interface{}, _ string) error {
+	return validateRunes(input, unicode.IsPrint, "input must contain only printable characters")
+}
+
+// NumericValidator checks if the input string contains only the digits 0 to 9
+func NumericValidator(input interface{}, _ string) error {
+	return validateRunes(input, isAsciiDigit, "input must contain only digits")
+}
+
+// LowercaseValidator checks if the input string contains no uppercase letters
+func LowercaseValidator(input interface{}, _ string) error {
+	return validateRunes(input, func(r rune) bool {
+		return !unicode.IsUpper(r) && !unicode.IsTitle(r)
+	}, "input must be lowercase")
+}
+
+// UppercaseValidator checks if the input string contains no lowercase letters
+func UppercaseValidator(input interface{}, _ string) error {
+	return validateRunes(input, func(r rune) bool {
+		return !unicode.IsLower(r) && !unicode.IsTitle(r)
+	}, "input must be uppercase")
+}
+
+// NoWhitespaceValidator checks if the input string contains no whitespace, including Unicode spaces and line breaks
+func NoWhitespaceValidator(input interface{}, _ string) error {
+	return validateRunes(input, func(r rune) bool {
+		return !unicode.IsSpace(r)
+	}, "input must not contain whitespace")
+}
+
+// UnicodeLettersValidator checks if the input string contains only letters of any script, including combining marks
+// such as the vowel signs of Devanagari
+func UnicodeLettersValidator(input interface{}, _ string) error {
+	return validateRunes(input, func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsMark(r)
+	}, "input must contain only letters")
+}
+
+// validateRunes checks that every rune of the input string satisfies valid, invalid UTF-8 is always rejected
+func validateRunes(input interface{}, valid func(rune) bool, message string) error {
+	if isEmptyOrNull(input) {
+		return nil
+	}
+
+	str, ok := getString(input)
+	if !ok {
+		return fmt.Errorf("failed to map the input to a string")
+	}
+	if !utf8.ValidString(str) || strings.IndexFunc(str, func(r rune) bool { return !valid(r) }) >= 0 {
+		return errors.New(message)
+	}
+	return nil
+}
+
+// getStringAndLength returns the input string and the length argument of the rune length validators.
+// It reports false for empty inputs, which are not validated
+func getStringAndLength(input interface{}, length string) (string, int, bool, error) {
+	if isEmptyOrNull(input) {
+		return "", 0, false, nil
+	}
+
+	str, ok := getString(input)
+	if !ok {
+		return "", 0, false, fmt.Errorf("failed to map the input to a string")
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(length))
+	if err != nil {
+		return "", 0, false, fmt.Errorf("failed to convert length to integer")
+	}
+	return str, n, true, nil
+}
+
+// isAsciiLetter reports whether the rune is a letter from a to z in either case
+func isAsciiLetter(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'
+}
+
+// isAsciiDigit reports whether the rune is a digit from 0 to 9
+func isAsciiDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
@@ -9,9 +9,22 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
-	"time"
+	"sync"
 )
 
+// Regular expressions used by the validators, compiled once
+var (
+	emailRegex   = regexp.MustCompile(`^[a-z0-9._%+-]+@[a-z0-9.-]+\.[a-z]{2,4}$`)
+	phoneRegex   = regexp.MustCompile(`^\+[1-9]\d{1,14}$`)
+	upperRegex   = regexp.MustCompile(`[A-Z]`)
+	lowerRegex   = regexp.MustCompile(`[a-z]`)
+	digitRegex   = regexp.MustCompile(`\d`)
+	specialRegex = regexp.MustCompile(`[\^$*.\[\]{}()?!"@#%&/,><':;|_~` + "`" + `"-]`)
+)
+
+// patternCache holds the compiled expressions of the pattern validator keyed by their pattern
+var patternCache sync.Map
+
 // RequiredValidator checks if the input is not empty for supported types
 func RequiredValidator(input interface{}, _ string) error {
 	val := reflect.ValueOf(input)
@@ -65,7 +78,6 @@ func EmailValidator(input interface{}, _ string) error {
 		return fmt.Errorf("failed to map the input to a string")
 	}
 
-	emailRegex := regexp.MustCompile(`^[a-z0-9._%+-]+@[a-z0-9.-]+\.[a-z]{2,4}$`)
 	if !emailRegex.MatchString(str) {
 		return fmt.Errorf("input is not a valid email address")
 	}
@@ -83,7 +95,6 @@ func PhoneValidator(input interface{}, _ string) error {
 	if !ok {
 		return fmt.Errorf("failed to map the input to a string")
 	}
-	phoneRegex := regexp.MustCompile(`^\+[1-9]\d{1,14}$`)
 	if !phoneRegex.MatchString(str) {
 		return fmt.Errorf("input is not a valid international phone number")
 	}
@@ -106,25 +117,25 @@ func StrongPasswordValidator(input interface{}, _ string) error {
 	}
 
 	// Check for at least one uppercase letter
-	hasUpper := regexp.MustCompile(`[A-Z]`).MatchString(str)
+	hasUpper := upperRegex.MatchString(str)
 	if !hasUpper {
 		return fmt.Errorf("password must contain at least one uppercase letter")
 	}
 
 	// Check for at least one lowercase letter
-	hasLower := regexp.MustCompile(`[a-z]`).MatchString(str)
+	hasLower := lowerRegex.MatchString(str)
 	if !hasLower {
 		return fmt.Errorf("password must contain at least one lowercase letter")
 	}
 
 	// Check for at least one digit
-	hasDigit := regexp.MustCompile(`\d`).MatchString(str)
+	hasDigit := digitRegex.MatchString(str)
 	if !hasDigit {
 		return fmt.Errorf("password must contain at least one digit")
 	}
 
 	// Check for at least one special character
-	hasSpecial := regexp.MustCompile(`[\^$*.\[\]{}()?!"@#%&/,><':;|_~` + "`" + `"-]`).MatchString(str)
+	hasSpecial := specialRegex.MatchString(str)
 	if !hasSpecial {
 		return fmt.Errorf("password must contain at least one special character")
 	}
@@ -132,8 +143,8 @@ func StrongPasswordValidator(input interface{}, _ string) error {
 	return nil
 }
 
-// DateValidator checks if the input string is a valid date in YYYY-MM-DD format
-func DateValidator(input interface{}, _ string) error {
+// PatternValidator checks if the input string matches the regular expression given as argument
+func PatternValidator(input interface{}, pattern string) error {
 	if isEmptyOrNull(input) {
 		return nil
 	}
@@ -142,49 +153,32 @@ func DateValidator(input interface{}, _ string) error {
 	if !ok {
 		return fmt.Errorf("failed to map the input to a string")
 	}
-	_, err := time.Parse("2006-01-02", str)
+	re, err := CompilePattern(pattern)
 	if err != nil {
-		return fmt.Errorf("input is not a valid date, expected format YYYY-MM-DD")
-	}
-	return nil
-}
-
-// TimeValidator checks if the input string is a valid time in HH:MM:SS format
-func TimeValidator(input interface{}, _ string) error {
-	if isEmptyOrNull(input) {
-		return nil
-	}
-
-	str, ok := getString(input)
-	if !ok {
-		return fmt.Errorf("failed to map the input to a string")
+		return err
 	}
-	_, err := time.Parse("15:04:05", str)
-	if err != nil {
-		return fmt.Errorf("input is not a valid time, expected format HH:MM:SS")
+	if !re.MatchString(str) {
+		return fmt.Errorf("input must match the pattern %s", pattern)
 	}
 	return nil
 }
 
-// DatetimeValidator checks if the input string is a valid datetime in YYYY-MM-DD HH:MM:SS with timezone format
-func DatetimeValidator(input interface{}, _ string) error {
-	if isEmptyOrNull(input) {
-		return nil
-	}
-
-	str, ok := getString(input)
-	if !ok {
-		return fmt.Errorf("failed to map the input to a string")
+// CompilePattern compiles the regular expression of the pattern validator, every distinct pattern is compiled only once
+func CompilePattern(pattern string) (*regexp.Regexp, error) {
+	if re, ok := patternCache.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
 	}
-	_, err := time.Parse("2006-01-02 15:04:05 MST", str)
+	re, err := regexp.Compile(pattern)
 	if err != nil {
-		return fmt.Errorf("input is not a valid datetime with timezone, expected format YYYY-MM-DD HH:MM:SS MST")
+		return nil, fmt.Errorf("invalid pattern '%s': %w", pattern, err)
 	}
-	return nil
+	cached, _ := patternCache.LoadOrStore(pattern, re)
+	return cached.(*regexp.Regexp), nil
 }
 
-// UrlValidator checks if the input string is a valid URL
-func UrlValidator(input interface{}, _ string) error {
+// UrlValidator checks if the input string is a valid URL, the optional argument restricts the scheme
+// (e.g., "https", or "http|https" for several schemes)
+func UrlValidator(input interface{}, schemes string) error {
 	if isEmptyOrNull(input) {
 		return nil
 	}
@@ -197,7 +191,16 @@ func UrlValidator(input interface{}, _ string) error {
 	if err != nil || u.Scheme == "" || u.Host == "" {
 		return fmt.Errorf("input is not a valid URL")
 	}
-	return nil
+
+	if strings.TrimSpace(schemes) == "" {
+		return nil
+	}
+	for _, scheme := range SplitArgs(schemes) {
+		if strings.EqualFold(u.Scheme, strings.TrimSpace(scheme)) {
+			return nil
+		}
+	}
+	return fmt.Errorf("input must be a URL with one of the following schemes: %s", schemes)
 }
 
 // IpValidator checks if the input string is a valid IP address
@@ -256,68 +259,18 @@ func MaxLengthValidator(input interface{}, length string) error {
 	return nil
 }
 
-func GreaterThanValidator(input interface{}, threshold string) error {
-	if isEmptyOrNull(input) {
-		return nil
-	}
-
-	number, thresh, err := convertToFloat64(input, threshold)
-	if err != nil {
-		return err
-	}
-	if number <= thresh {
-		return fmt.Errorf("input must be greater than %s", threshold)
-	}
-	return nil
-}
-
-func LessThanValidator(input interface{}, threshold string) error {
-	if isEmptyOrNull(input) {
-		return nil
-	}
-
-	number, thresh, err := convertToFloat64(input, threshold)
-	if err != nil {
-		return err
-	}
-	if number >= thresh {
-		return fmt.Errorf("input must be less than %s", threshold)
-	}
-	return nil
+// EnumValidator checks if the input string is one of the allowed values, separated by '|' or '-'
+func EnumValidator(input interface{}, allowedValues string) error {
+	return enumValidator(input, splitValues(allowedValues, "-"), allowedValues)
 }
 
-func GreaterThanOrEqualValidator(input interface{}, threshold string) error {
-	if isEmptyOrNull(input) {
-		return nil
-	}
-
-	number, thresh, err := convertToFloat64(input, threshold)
-	if err != nil {
-		return err
-	}
-	if number < thresh {
-		return fmt.Errorf("input must be greater than or equal to %s", threshold)
-	}
-	return nil
+// EnumValuesValidator checks if the input string is exactly one of the allowed values
+func EnumValuesValidator(input interface{}, allowedValues []string) error {
+	return enumValidator(input, allowedValues, strings.Join(allowedValues, "|"))
 }
 
-func LessThanOrEqualValidator(input interface{}, threshold string) error {
-	if isEmptyOrNull(input) {
-		return nil
-	}
-
-	number, thresh, err := convertToFloat64(input, threshold)
-	if err != nil {
-		return err
-	}
-	if number > thresh {
-		return fmt.Errorf("input must be less than or equal to %s", threshold)
-	}
-	return nil
-}
-
-// EnumValidator checks if the input string is one of the allowed values
-func EnumValidator(input interface{}, allowedValues string) error {
+// enumValidator checks the input against the values and reports allowedValues when it matches none of them
+func enumValidator(input interface{}, values []string, allowedValues string) error {
 	if isEmptyOrNull(input) {
 		return nil
 	}
@@ -326,9 +279,8 @@ func EnumValidator(input interface{}, allowedValues string) error {
 	if !ok {
 		return fmt.Errorf("failed to map the input to a string")
 	}
-	values := strings.Split(allowedValues, "-")
 	for _, value := range values {
-		if strings.TrimSpace(str) == strings.TrimSpace(value) {
+		if strings.TrimSpace(str) == value {
 			return nil
 		}
 	}
@@ -348,8 +300,18 @@ func BooleanValidator(input interface{}, _ string) error {
 	return nil
 }
 
-// ContainsValidator checks if the input string contains one of the allowed values
+// ContainsValidator checks if the input string contains one of the allowed values, separated by '|' or ','
 func ContainsValidator(input interface{}, allowedValues string) error {
+	return containsValidator(input, splitValues(allowedValues, ","), allowedValues)
+}
+
+// ContainsValuesValidator checks if the input string contains one of the allowed values exactly as given
+func ContainsValuesValidator(input interface{}, allowedValues []string) error {
+	return containsValidator(input, allowedValues, strings.Join(allowedValues, "|"))
+}
+
+// containsValidator checks the input against the values and reports allowedValues when it contains none of them
+func containsValidator(input interface{}, values []string, allowedValues string) error {
 	if isEmptyOrNull(input) {
 		return nil
 	}
@@ -358,17 +320,21 @@ func ContainsValidator(input interface{}, allowedValues string) error {
 	if !ok {
 		return fmt.Errorf("failed to map the input to a string")
 	}
-	values := strings.Split(allowedValues, ",")
 	for _, value := range values {
-		if strings.Contains(str, strings.TrimSpace(value)) {
+		if strings.Contains(str, value) {
 			return nil
 		}
 	}
 	return fmt.Errorf("input must contain one of the following values: %s", allowedValues)
 }
 
-// NotContainsValidator checks if the input string does not contain any of the disallowed values
+// NotContainsValidator checks if the input string does not contain any of the disallowed values, separated by '|' or ','
 func NotContainsValidator(input interface{}, disallowedValues string) error {
+	return NotContainsValuesValidator(input, splitValues(disallowedValues, ","))
+}
+
+// NotContainsValuesValidator checks if the input string does not contain any of the disallowed values exactly as given
+func NotContainsValuesValidator(input interface{}, disallowedValues []string) error {
 	if isEmptyOrNull(input) {
 		return nil
 	}
@@ -377,56 +343,14 @@ func NotContainsValidator(input interface{}, disallowedValues string) error {
 	if !ok {
 		return fmt.Errorf("failed to map the input to a string")
 	}
-	values := strings.Split(disallowedValues, ",")
-	for _, value := range values {
-		if strings.Contains(str, strings.TrimSpace(value)) {
+	for _, value := range disallowedValues {
+		if strings.Contains(str, value) {
 			return fmt.Errorf("input must not contain the following value: %s", value)
 		}
 	}
 	return nil
 }
 
-// RangeValidator checks if a number is between two numbers specified with a dash (e.g., "10-100")
-func RangeValidator(input interface{}, rangeStr string) error {
-	if isEmptyOrNull(input) {
-		return nil
-	}
-
-	val := reflect.ValueOf(input)
-	if val.Kind() != reflect.Int && val.Kind() != reflect.Float64 && val.Kind() != reflect.Float32 {
-		return fmt.Errorf("input must be a number")
-	}
-
-	parts := strings.Split(rangeStr, "-")
-	if len(parts) != 2 {
-		return fmt.Errorf("range format is incorrect, must be 'min-max'")
-	}
-
-	min, err := strconv.ParseFloat(parts[0], 64)
-	if err != nil {
-		return fmt.Errorf("failed to parse minimum value: %s", parts[0])
-	}
-
-	max, err := strconv.ParseFloat(parts[1], 64)
-	if err != nil {
-		return fmt.Errorf("failed to parse maximum value: %s", parts[1])
-	}
-
-	if min > max {
-		return fmt.Errorf("minimum value must be less than maximum value")
-	}
-
-	number, _, err := convertToFloat64(input, parts[0])
-	if err != nil {
-		return err
-	}
-
-	if number < min || number > max {
-		return fmt.Errorf("input must be between %s and %s", parts[0], parts[1])
-	}
-	return nil
-}
-
 // StartsWidthValidator validates that a string starts with a specified substring
 func StartsWidthValidator(input interface{}, prefix string) error {
 	if isEmptyOrNull(input) {
@@ -459,19 +383,76 @@ func EndsWithValidator(input interface{}, suffix string) error {
 	return nil
 }
 
-func convertToFloat64(input interface{}, threshold string) (float64, float64, error) {
-	val := reflect.ValueOf(input)
-	if val.Kind() != reflect.Int && val.Kind() != reflect.Int64 && val.Kind() != reflect.Float64 && val.Kind() != reflect.Float32 {
-		return 0, 0, fmt.Errorf("input must be a number")
+// MinItemsValidator checks if a slice, array or map has at least the specified number of items
+func MinItemsValidator(input interface{}, count string) error {
+	collection, ok := getCollection(input)
+	if !ok {
+		return fmt.Errorf("input must be a slice, array or map")
+	}
+	if !collection.IsValid() {
+		return nil
 	}
 
-	thresh, err := strconv.ParseFloat(threshold, 64)
+	minItems, err := strconv.Atoi(count)
 	if err != nil {
-		return 0, 0, fmt.Errorf("failed to convert threshold to float: %v", err)
+		return fmt.Errorf("failed to convert item count to integer")
+	}
+	if collection.Len() < minItems {
+		return fmt.Errorf("input must contain at least %s items", count)
 	}
+	return nil
+}
 
-	number := val.Convert(reflect.TypeOf(thresh)).Float()
-	return number, thresh, nil
+// MaxItemsValidator checks if a slice, array or map has at most the specified number of items
+func MaxItemsValidator(input interface{}, count string) error {
+	collection, ok := getCollection(input)
+	if !ok {
+		return fmt.Errorf("input must be a slice, array or map")
+	}
+	if !collection.IsValid() {
+		return nil
+	}
+
+	maxItems, err := strconv.Atoi(count)
+	if err != nil {
+		return fmt.Errorf("failed to convert item count to integer")
+	}
+	if collection.Len() > maxItems {
+		return fmt.Errorf("input must contain at most %s items", count)
+	}
+	return nil
+}
+
+// UniqueValidator checks that the elements of a slice or array, or the values of a map, are all different
+func UniqueValidator(input interface{}, _ string) error {
+	collection, ok := getCollection(input)
+	if !ok {
+		return fmt.Errorf("input must be a slice, array or map")
+	}
+	if !collection.IsValid() {
+		return nil
+	}
+
+	var items []reflect.Value
+	if collection.Kind() == reflect.Map {
+		iter := collection.MapRange()
+		for iter.Next() {
+			items = append(items, iter.Value())
+		}
+	} else {
+		for i := 0; i < collection.Len(); i++ {
+			items = append(items, collection.Index(i))
+		}
+	}
+
+	for i := 0; i < len(items); i++ {
+		for j := i + 1; j < len(items); j++ {
+			if reflect.DeepEqual(items[i].Interface(), items[j].Interface()) {
+				return fmt.Errorf("input must contain unique values, duplicate found: %v", items[i].Interface())
+			}
+		}
+	}
+	return nil
 }
 
 // IsEmptyOrNull checks if the input is empty or null for various types
@@ -489,9 +470,10 @@ func isEmptyOrNull(input interface{}) bool {
 		return v.Len() == 0
 	case reflect.Ptr, reflect.Interface:
 		return v.IsNil()
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
-		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint() == 0
 	case reflect.Struct:
 		return false
 	default:
@@ -499,6 +481,27 @@ func isEmptyOrNull(input interface{}) bool {
 	}
 }
 
+// getCollection dereferences the input and returns it if it is a slice, array or map.
+// It returns an invalid value and true for nil inputs, so optional collections are not validated.
+func getCollection(input interface{}) (reflect.Value, bool) {
+	value := reflect.ValueOf(input)
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return reflect.Value{}, true
+		}
+		value = value.Elem()
+	}
+
+	switch value.Kind() {
+	case reflect.Invalid:
+		return reflect.Value{}, true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return value, true
+	default:
+		return reflect.Value{}, false
+	}
+}
+
 // getString attempts to convert the input to a string, returning the string and a boolean indicating success
 func getString(input interface{}) (string, bool) {
 	value := reflect.ValueOf(input)
@@ -517,6 +520,30 @@ func getString(input interface{}) (string, bool) {
 	return str, true
 }
 
+// getNumber converts a number of any numeric kind, or a numeric string, to a float64
+func getNumber(input interface{}) (float64, bool) {
+	value := reflect.ValueOf(input)
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return 0, false
+		}
+		value = value.Elem()
+	}
+
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(value.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), true
+	case reflect.String:
+		number, err := strconv.ParseFloat(strings.TrimSpace(value.String()), 64)
+		return number, err == nil
+	}
+	return 0, false
+}
+
 func dereferenceBool(input interface{}) interface{} {
 	val := reflect.ValueOf(input)
 	if val.Kind() == reflect.Ptr && val.Elem().Kind() == reflect.Bool {
@@ -527,3 +554,36 @@ func dereferenceBool(input interface{}) interface{} {
 	}
 	return input
 }
+
+// SplitArgs splits an argument written as several quoted values in a tag, e.g. enum:'a'|'b', into its values.
+// The values are joined with '|', in which "\|" and "\\" are escapes. Use it in custom validators that accept a list of values
+func SplitArgs(arg string) []string {
+	var values []string
+	var value strings.Builder
+	for i := 0; i < len(arg); i++ {
+		switch {
+		case arg[i] == '\\' && i+1 < len(arg):
+			i++
+			value.WriteByte(arg[i])
+		case arg[i] == '|':
+			values = append(values, value.String())
+			value.Reset()
+		default:
+			value.WriteByte(arg[i])
+		}
+	}
+	return append(values, value.String())
+}
+
+// splitValues splits a list of values written with '|', or falls back to the separator of the older unquoted syntax.
+// The values are trimmed
+func splitValues(arg, separator string) []string {
+	values := SplitArgs(arg)
+	if len(values) == 1 {
+		values = strings.Split(arg, separator)
+	}
+	for i, value := range values {
+		values[i] = strings.TrimSpace(value)
+	}
+	return values
+}